@@ -0,0 +1,10 @@
+ephemeral "tfcoremock_simple_resource" "example" {
+  id       = "my-simple-resource"
+  renew_at = "30s"
+
+  bool    = true
+  number  = 0
+  string  = "Hello, world!"
+  float   = 0
+  integer = 0
+}
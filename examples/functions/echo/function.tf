@@ -0,0 +1,3 @@
+output "example" {
+  value = provider::tfcoremock::echo({ name = "example" })
+}
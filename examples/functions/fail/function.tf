@@ -0,0 +1,4 @@
+# Fails with an error attributed to the "example" argument.
+output "example" {
+  value = provider::tfcoremock::fail(2, "forced failure", "example")
+}
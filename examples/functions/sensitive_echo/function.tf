@@ -0,0 +1,9 @@
+variable "password" {
+  type      = string
+  sensitive = true
+}
+
+output "example" {
+  value     = provider::tfcoremock::sensitive_echo(var.password)
+  sensitive = true
+}
@@ -0,0 +1,5 @@
+resource "tfcoremock_simple_resource" "example" {}
+
+output "example" {
+  value = provider::tfcoremock::unknown_passthrough(tfcoremock_simple_resource.example.id)
+}
@@ -0,0 +1,3 @@
+output "example" {
+  value = provider::tfcoremock::variadic_count("one", 2, null)
+}
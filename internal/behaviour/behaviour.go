@@ -0,0 +1,375 @@
+// Copyright IBM Corp. 2022, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package behaviour
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+)
+
+// Operation identifies the provider operation that a behaviour can target.
+type Operation string
+
+const (
+	Create Operation = "create"
+	Read   Operation = "read"
+	Update Operation = "update"
+	Delete Operation = "delete"
+
+	// Plan is used for plans that make no changes to the resource, but when
+	// used within a Target it also matches all the more specific plan
+	// operations.
+	Plan        Operation = "plan"
+	PlanCreate  Operation = "plan_create"
+	PlanUpdate  Operation = "plan_update"
+	PlanReplace Operation = "plan_replace"
+	PlanDelete  Operation = "plan_delete"
+
+	Import         Operation = "import"
+	ReadDataSource Operation = "read_data_source"
+	List           Operation = "list"
+	Invoke         Operation = "invoke"
+)
+
+// Operations contains every operation that a behaviour can target.
+var Operations = []Operation{Create, Read, Update, Delete, Plan, PlanCreate, PlanUpdate, PlanReplace, PlanDelete, Import, ReadDataSource, List, Invoke}
+
+// IsPlan returns true if the operation happens during the plan.
+func (operation Operation) IsPlan() bool {
+	switch operation {
+	case Plan, PlanCreate, PlanUpdate, PlanReplace, PlanDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// Match describes how the ID and resource type patterns of a Target are
+// compared against the actual values.
+type Match string
+
+const (
+	MatchExact Match = "exact"
+	MatchGlob  Match = "glob"
+	MatchRegex Match = "regex"
+)
+
+// Target describes the set of operations, resource types and resource IDs a
+// behaviour applies to.
+//
+// Empty patterns match everything, as does an empty list of operations. The
+// patterns are treated as globs unless Match says otherwise.
+type Target struct {
+	Operations   []Operation `json:"operations,omitempty"`
+	ResourceType string      `json:"resource_type,omitempty"`
+	ID           string      `json:"id,omitempty"`
+	Match        Match       `json:"match,omitempty"`
+}
+
+// Validate checks the operations, match mode and patterns in the target are
+// all valid.
+func (t Target) Validate() error {
+	for _, operation := range t.Operations {
+		if !slices.Contains(Operations, operation) {
+			return fmt.Errorf("unrecognized operation '%s'", operation)
+		}
+	}
+
+	switch t.Match {
+	case "", MatchExact, MatchGlob, MatchRegex:
+	default:
+		return fmt.Errorf("unrecognized match mode '%s'", t.Match)
+	}
+
+	for _, pattern := range []string{t.ResourceType, t.ID} {
+		if _, err := t.matches(pattern, ""); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Matches returns true if the target applies to the given operation on the
+// specified resource.
+func (t Target) Matches(operation Operation, resourceType string, id string) bool {
+	if len(t.Operations) > 0 && !slices.Contains(t.Operations, operation) {
+		if !operation.IsPlan() || !slices.Contains(t.Operations, Plan) {
+			return false
+		}
+	}
+
+	if ok, err := t.matches(t.ResourceType, resourceType); err != nil || !ok {
+		return false
+	}
+
+	if ok, err := t.matches(t.ID, id); err != nil || !ok {
+		return false
+	}
+
+	return true
+}
+
+func (t Target) matches(pattern string, value string) (bool, error) {
+	if len(pattern) == 0 {
+		return true, nil
+	}
+
+	switch t.Match {
+	case MatchExact:
+		return pattern == value, nil
+	case MatchRegex:
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return false, fmt.Errorf("invalid regex '%s': %w", pattern, err)
+		}
+		return re.MatchString(value), nil
+	default:
+		ok, err := path.Match(pattern, value)
+		if err != nil {
+			return false, fmt.Errorf("invalid glob '%s': %w", pattern, err)
+		}
+		return ok, nil
+	}
+}
+
+// Failure forces the targeted operations to fail, according to its schedule.
+//
+// The diagnostic raised by the failure can be customised, and failures that
+// only raise a warning will allow the operation to complete.
+//
+// Partial failures are raised only after the resource has been written and the
+// new state returned, mimicking a remote object that was created or updated
+// before the operation failed.
+//
+// Failures for list operations can be raised after a number of results have
+// already been returned.
+type Failure struct {
+	Target
+	Schedule
+	Diagnostic
+
+	Partial      bool  `json:"partial,omitempty"`
+	AfterResults int64 `json:"after_results,omitempty"`
+}
+
+// Validate checks the target, schedule and diagnostic of the failure are all
+// valid.
+func (f Failure) Validate() error {
+	if err := f.Target.Validate(); err != nil {
+		return err
+	}
+	if err := f.Schedule.Validate(); err != nil {
+		return err
+	}
+	if err := f.Diagnostic.Validate(); err != nil {
+		return err
+	}
+
+	if f.Partial {
+		if len(f.Operations) == 0 {
+			return errors.New("partial failures must target the create or update operations")
+		}
+		for _, operation := range f.Operations {
+			if operation != Create && operation != Update {
+				return fmt.Errorf("partial failures cannot target the %s operation", operation)
+			}
+		}
+	}
+
+	if f.AfterResults < 0 {
+		return errors.New("after_results cannot be negative")
+	}
+	if f.AfterResults > 0 && !slices.Equal(f.Operations, []Operation{List}) {
+		return errors.New("after_results can only be used by failures that only target the list operation")
+	}
+	return nil
+}
+
+// Behaviours holds the complete set of behaviours that have been configured
+// for the provider.
+type Behaviours struct {
+	Failures  []Failure  `json:"failures,omitempty"`
+	Deferrals []Deferral `json:"deferrals,omitempty"`
+	Delays    []Delay    `json:"delays,omitempty"`
+	Crashes   []Crash    `json:"crashes,omitempty"`
+	Drifts    []Drift    `json:"drifts,omitempty"`
+
+	Inconsistencies []Inconsistency `json:"inconsistencies,omitempty"`
+}
+
+// Validate checks every behaviour is valid, reporting the position of the
+// first invalid behaviour.
+func (b Behaviours) Validate() error {
+	for ix, failure := range b.Failures {
+		if err := failure.Validate(); err != nil {
+			return fmt.Errorf("failures[%d]: %w", ix, err)
+		}
+	}
+	for ix, deferral := range b.Deferrals {
+		if err := deferral.Validate(); err != nil {
+			return fmt.Errorf("deferrals[%d]: %w", ix, err)
+		}
+	}
+	for ix, delay := range b.Delays {
+		if err := delay.Validate(); err != nil {
+			return fmt.Errorf("delays[%d]: %w", ix, err)
+		}
+	}
+	for ix, crash := range b.Crashes {
+		if err := crash.Validate(); err != nil {
+			return fmt.Errorf("crashes[%d]: %w", ix, err)
+		}
+	}
+	for ix, drift := range b.Drifts {
+		if err := drift.Validate(); err != nil {
+			return fmt.Errorf("drifts[%d]: %w", ix, err)
+		}
+	}
+	for ix, inconsistency := range b.Inconsistencies {
+		if err := inconsistency.Validate(); err != nil {
+			return fmt.Errorf("inconsistencies[%d]: %w", ix, err)
+		}
+	}
+	return nil
+}
+
+// Merge returns the behaviours in b followed by the behaviours in other. As
+// the first matching behaviour of each kind is used, b takes precedence.
+func (b Behaviours) Merge(other Behaviours) Behaviours {
+	return Behaviours{
+		Failures:  slices.Concat(b.Failures, other.Failures),
+		Deferrals: slices.Concat(b.Deferrals, other.Deferrals),
+		Delays:    slices.Concat(b.Delays, other.Delays),
+		Crashes:   slices.Concat(b.Crashes, other.Crashes),
+		Drifts:    slices.Concat(b.Drifts, other.Drifts),
+
+		Inconsistencies: slices.Concat(b.Inconsistencies, other.Inconsistencies),
+	}
+}
+
+// Effects holds the scheduled behaviours that apply to a single invocation of
+// an operation.
+type Effects struct {
+	Failure *Failure
+	Delay   *Delay
+	Crash   *Crash
+}
+
+// Evaluate returns the first failure, delay and crash that target and are
+// scheduled to trigger on the given operation on the specified resource.
+//
+// The invocation is recorded in the counter once if any of the matching
+// behaviours have a schedule.
+func (b Behaviours) Evaluate(operation Operation, resourceType string, id string, counter Counter) (Effects, error) {
+	failures, failuresScheduled := matching(b.Failures, operation, resourceType, id)
+	delays, delaysScheduled := matching(b.Delays, operation, resourceType, id)
+	crashes, crashesScheduled := matching(b.Crashes, operation, resourceType, id)
+
+	if len(failures) == 0 && len(delays) == 0 && len(crashes) == 0 {
+		return Effects{}, nil
+	}
+
+	key := fmt.Sprintf("%s/%s/%s", operation, resourceType, id)
+
+	var call int64
+	if failuresScheduled || delaysScheduled || crashesScheduled {
+		var err error
+		if call, err = counter.Increment(key); err != nil {
+			return Effects{}, fmt.Errorf("failed to count invocations: %w", err)
+		}
+	}
+
+	return Effects{
+		Failure: triggered(failures, key, call),
+		Delay:   triggered(delays, key, call),
+		Crash:   triggered(crashes, key, call),
+	}, nil
+}
+
+// scheduled is implemented by every behaviour that embeds both a Target and a
+// Schedule.
+type scheduled interface {
+	Matches(operation Operation, resourceType string, id string) bool
+	IsZero() bool
+	Triggers(key string, call int64) bool
+}
+
+// matching returns the behaviours that target the given operation on the
+// specified resource, and whether any of them have a schedule.
+func matching[T scheduled](behaviours []T, operation Operation, resourceType string, id string) ([]T, bool) {
+	var matches []T
+	hasSchedule := false
+	for _, behaviour := range behaviours {
+		if behaviour.Matches(operation, resourceType, id) {
+			matches = append(matches, behaviour)
+			hasSchedule = hasSchedule || !behaviour.IsZero()
+		}
+	}
+	return matches, hasSchedule
+}
+
+// triggered returns the first of the behaviours that triggers on the given
+// call, or nil if none of them do.
+func triggered[T scheduled](behaviours []T, key string, call int64) *T {
+	for _, behaviour := range behaviours {
+		if behaviour.Triggers(key, call) {
+			return &behaviour
+		}
+	}
+	return nil
+}
+
+// Deferral returns the first deferral that targets the given operation on the
+// specified resource, or nil if the operation should not be deferred.
+//
+// The unknown attributes are the top-level attributes and blocks in the
+// configuration that contain unknown values, if the operation has a
+// configuration.
+func (b Behaviours) Deferral(operation Operation, resourceType string, id string, unknown []string) *Deferral {
+	for _, deferral := range b.Deferrals {
+		if deferral.Matches(operation, resourceType, id) && deferral.Defers(unknown) {
+			return &deferral
+		}
+	}
+	return nil
+}
+
+// Drift returns the first drift that targets and is scheduled to trigger on
+// the given refresh of the specified resource, or nil if the resource hasn't
+// drifted.
+//
+// The refresh is recorded in the counter if any of the matching drifts have a
+// schedule.
+func (b Behaviours) Drift(resourceType string, id string, counter Counter) (*Drift, error) {
+	drifts, scheduled := matching(b.Drifts, Read, resourceType, id)
+	if len(drifts) == 0 {
+		return nil, nil
+	}
+
+	key := fmt.Sprintf("drift/%s/%s", resourceType, id)
+
+	var call int64
+	if scheduled {
+		var err error
+		if call, err = counter.Increment(key); err != nil {
+			return nil, fmt.Errorf("failed to count refreshes: %w", err)
+		}
+	}
+	return triggered(drifts, key, call), nil
+}
+
+// Inconsistency returns the first inconsistency that targets the given write
+// to the specified resource, or nil if reads of the resource should be
+// consistent.
+func (b Behaviours) Inconsistency(operation Operation, resourceType string, id string) *Inconsistency {
+	for _, inconsistency := range b.Inconsistencies {
+		if inconsistency.Matches(operation, resourceType, id) {
+			return &inconsistency
+		}
+	}
+	return nil
+}
@@ -0,0 +1,246 @@
+// Copyright IBM Corp. 2022, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package behaviour
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestTarget_Matches(t *testing.T) {
+	testCases := []struct {
+		TestCase     string
+		Target       Target
+		Operation    Operation
+		ResourceType string
+		ID           string
+		Expected     bool
+	}{
+		{
+			TestCase:     "empty",
+			Target:       Target{},
+			Operation:    Create,
+			ResourceType: "tfcoremock_simple_resource",
+			ID:           "id",
+			Expected:     true,
+		},
+		{
+			TestCase:     "wrong_operation",
+			Target:       Target{Operations: []Operation{Delete}},
+			Operation:    Create,
+			ResourceType: "tfcoremock_simple_resource",
+			ID:           "id",
+			Expected:     false,
+		},
+		{
+			TestCase:     "plan",
+			Target:       Target{Operations: []Operation{Plan}},
+			Operation:    PlanDelete,
+			ResourceType: "tfcoremock_simple_resource",
+			ID:           "id",
+			Expected:     true,
+		},
+		{
+			TestCase:     "wrong_plan",
+			Target:       Target{Operations: []Operation{PlanCreate}},
+			Operation:    PlanDelete,
+			ResourceType: "tfcoremock_simple_resource",
+			ID:           "id",
+			Expected:     false,
+		},
+		{
+			TestCase:     "exact",
+			Target:       Target{ID: "db-*", Match: MatchExact},
+			Operation:    Create,
+			ResourceType: "tfcoremock_simple_resource",
+			ID:           "db-0",
+			Expected:     false,
+		},
+		{
+			TestCase:     "glob",
+			Target:       Target{ID: "db-*", ResourceType: "tfcoremock_dynamic_*"},
+			Operation:    Create,
+			ResourceType: "tfcoremock_dynamic_database",
+			ID:           "db-0",
+			Expected:     true,
+		},
+		{
+			TestCase:     "glob_wrong_type",
+			Target:       Target{ID: "db-*", ResourceType: "tfcoremock_dynamic_*"},
+			Operation:    Create,
+			ResourceType: "tfcoremock_simple_resource",
+			ID:           "db-0",
+			Expected:     false,
+		},
+		{
+			TestCase:     "regex",
+			Target:       Target{ID: "^db-[0-9]+$", Match: MatchRegex},
+			Operation:    Create,
+			ResourceType: "tfcoremock_simple_resource",
+			ID:           "db-10",
+			Expected:     true,
+		},
+		{
+			TestCase:     "regex_no_match",
+			Target:       Target{ID: "^db-[0-9]+$", Match: MatchRegex},
+			Operation:    Create,
+			ResourceType: "tfcoremock_simple_resource",
+			ID:           "db-a",
+			Expected:     false,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.TestCase, func(t *testing.T) {
+			if err := testCase.Target.Validate(); err != nil {
+				t.Fatalf("found unexpected error in Validate(): %v", err)
+			}
+
+			actual := testCase.Target.Matches(testCase.Operation, testCase.ResourceType, testCase.ID)
+			if actual != testCase.Expected {
+				t.Fatalf("expected %t but found %t", testCase.Expected, actual)
+			}
+		})
+	}
+}
+
+func TestTarget_Validate(t *testing.T) {
+	testCases := []struct {
+		TestCase string
+		Target   Target
+	}{
+		{
+			TestCase: "invalid_operation",
+			Target:   Target{Operations: []Operation{"destroy"}},
+		},
+		{
+			TestCase: "invalid_match",
+			Target:   Target{Match: "fuzzy"},
+		},
+		{
+			TestCase: "invalid_glob",
+			Target:   Target{ID: "["},
+		},
+		{
+			TestCase: "invalid_regex",
+			Target:   Target{ID: "(", Match: MatchRegex},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.TestCase, func(t *testing.T) {
+			if err := testCase.Target.Validate(); err == nil {
+				t.Fatalf("expected error in Validate() but found none")
+			}
+		})
+	}
+}
+
+func TestFailure_Validate(t *testing.T) {
+	testCases := []struct {
+		TestCase string
+		Failure  Failure
+	}{
+		{
+			TestCase: "partial_without_operations",
+			Failure:  Failure{Partial: true},
+		},
+		{
+			TestCase: "partial_read",
+			Failure:  Failure{Target: Target{Operations: []Operation{Create, Read}}, Partial: true},
+		},
+		{
+			TestCase: "negative_after_results",
+			Failure:  Failure{Target: Target{Operations: []Operation{List}}, AfterResults: -1},
+		},
+		{
+			TestCase: "after_results_without_list",
+			Failure:  Failure{Target: Target{Operations: []Operation{Read}}, AfterResults: 1},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.TestCase, func(t *testing.T) {
+			if err := testCase.Failure.Validate(); err == nil {
+				t.Fatalf("expected error in Validate() but found none")
+			}
+		})
+	}
+}
+
+func TestSchedule_Triggers(t *testing.T) {
+	half := 0.5
+
+	testCases := []struct {
+		TestCase string
+		Schedule Schedule
+		Expected []bool
+	}{
+		{
+			TestCase: "empty",
+			Schedule: Schedule{},
+			Expected: []bool{true, true, true},
+		},
+		{
+			TestCase: "on_call",
+			Schedule: Schedule{OnCall: 2},
+			Expected: []bool{false, true, false},
+		},
+		{
+			TestCase: "first_calls",
+			Schedule: Schedule{FirstCalls: 2},
+			Expected: []bool{true, true, false},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.TestCase, func(t *testing.T) {
+			for ix, expected := range testCase.Expected {
+				if actual := testCase.Schedule.Triggers("key", int64(ix+1)); actual != expected {
+					t.Fatalf("expected %t for call %d but found %t", expected, ix+1, actual)
+				}
+			}
+		})
+	}
+
+	t.Run("probability", func(t *testing.T) {
+		schedule := Schedule{Probability: &half, Seed: 42}
+		for call := int64(1); call <= 10; call++ {
+			if schedule.Triggers("key", call) != schedule.Triggers("key", call) {
+				t.Fatalf("expected call %d to trigger consistently", call)
+			}
+		}
+	})
+}
+
+func TestFileCounter_Increment(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "counts.json")
+
+	for expected := int64(1); expected <= 3; expected++ {
+		// Use a new counter each time to make sure the counts come from the
+		// file and not from memory.
+		counter := &FileCounter{File: file}
+		actual, err := counter.Increment("key")
+		if err != nil {
+			t.Fatalf("found unexpected error in Increment(): %v", err)
+		}
+		if actual != expected {
+			t.Fatalf("expected %d but found %d", expected, actual)
+		}
+	}
+}
+
+func TestPrefixedCounter_Increment(t *testing.T) {
+	counter := &MemoryCounter{}
+	prefixed := PrefixedCounter{Prefix: "prefix/", Counter: counter}
+
+	if actual, err := prefixed.Increment("key"); err != nil || actual != 1 {
+		t.Fatalf("expected 1 from the prefixed counter but found %d (%v)", actual, err)
+	}
+
+	// The prefixed count is separate from the count for the same key in the
+	// underlying counter.
+	if actual, err := counter.Increment("key"); err != nil || actual != 1 {
+		t.Fatalf("expected 1 from the underlying counter but found %d (%v)", actual, err)
+	}
+	if actual, err := counter.Increment("prefix/key"); err != nil || actual != 2 {
+		t.Fatalf("expected 2 from the underlying counter but found %d (%v)", actual, err)
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright IBM Corp. 2022, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package behaviour
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Counter records how many times each operation has been invoked, so that
+// scheduled behaviours know whether they should trigger.
+type Counter interface {
+	// Increment records a new invocation for the given key and returns the
+	// total number of invocations made for the key so far, including this one.
+	Increment(key string) (int64, error)
+}
+
+var _ Counter = &FileCounter{}
+var _ Counter = &MemoryCounter{}
+var _ Counter = PrefixedCounter{}
+
+// FileCounter persists the invocation counts into a JSON file, so the counts
+// are shared between subsequent runs of the provider.
+type FileCounter struct {
+	File string
+
+	mutex sync.Mutex
+}
+
+func (counter *FileCounter) Increment(key string) (int64, error) {
+	counter.mutex.Lock()
+	defer counter.mutex.Unlock()
+
+	counts := make(map[string]int64)
+
+	jsonData, err := os.ReadFile(counter.File)
+	if err != nil && !os.IsNotExist(err) {
+		return 0, err
+	}
+	if len(jsonData) > 0 {
+		if err := json.Unmarshal(jsonData, &counts); err != nil {
+			return 0, fmt.Errorf("failed to unmarshal %s: %w", counter.File, err)
+		}
+	}
+
+	counts[key]++
+
+	if jsonData, err = json.MarshalIndent(counts, "", "  "); err != nil {
+		return 0, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(counter.File), 0700); err != nil {
+		return 0, err
+	}
+
+	if err := os.WriteFile(counter.File, jsonData, 0644); err != nil {
+		return 0, err
+	}
+
+	return counts[key], nil
+}
+
+// MemoryCounter holds the invocation counts in memory, so the counts are reset
+// every time the provider is restarted.
+type MemoryCounter struct {
+	counts map[string]int64
+	mutex  sync.Mutex
+}
+
+func (counter *MemoryCounter) Increment(key string) (int64, error) {
+	counter.mutex.Lock()
+	defer counter.mutex.Unlock()
+
+	if counter.counts == nil {
+		counter.counts = make(map[string]int64)
+	}
+	counter.counts[key]++
+	return counter.counts[key], nil
+}
+
+// PrefixedCounter records invocations in another counter, under keys that
+// start with Prefix, so its counts never collide with counts recorded directly
+// in the other counter.
+type PrefixedCounter struct {
+	Prefix  string
+	Counter Counter
+}
+
+func (counter PrefixedCounter) Increment(key string) (int64, error) {
+	return counter.Counter.Increment(counter.Prefix + key)
+}
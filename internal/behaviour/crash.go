@@ -0,0 +1,80 @@
+// Copyright IBM Corp. 2022, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package behaviour
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+// CrashMode is the way in which a crash takes down the provider.
+type CrashMode string
+
+const (
+	CrashPanic CrashMode = "panic"
+	CrashExit  CrashMode = "exit"
+)
+
+// exit is swapped out by the tests, so they can check the provider would have
+// exited without actually exiting.
+var exit = os.Exit
+
+// Crash forces the provider to crash during the targeted operations, according
+// to its schedule.
+//
+// A crash either panics within the goroutine handling the operation, or exits
+// the whole provider process immediately with ExitCode. ExitCode defaults to 1,
+// as exiting successfully in the middle of an operation is still a crash.
+type Crash struct {
+	Target
+	Schedule
+
+	Mode     CrashMode `json:"mode,omitempty"`
+	ExitCode int       `json:"exit_code,omitempty"`
+}
+
+// Validate checks the target, schedule and mode of the crash are all valid.
+func (c Crash) Validate() error {
+	if err := c.Target.Validate(); err != nil {
+		return err
+	}
+	if err := c.Schedule.Validate(); err != nil {
+		return err
+	}
+
+	switch c.Mode {
+	case "", CrashPanic:
+		if c.ExitCode != 0 {
+			return errors.New("exit_code can only be used when mode is exit")
+		}
+	case CrashExit:
+		if c.ExitCode < 0 || c.ExitCode > 125 {
+			return fmt.Errorf("exit_code must be between 0 and 125, where 0 means the default of 1, but was %d", c.ExitCode)
+		}
+	default:
+		return fmt.Errorf("unrecognized crash mode '%s'", c.Mode)
+	}
+	return nil
+}
+
+// Trigger crashes the provider during the given operation on the specified
+// resource. It does not return, unless exit has been replaced by a test.
+func (c Crash) Trigger(operation Operation, resourceType string, id string) {
+	msg := fmt.Sprintf("tfcoremock: forced crash during %s of %s with id '%s'", operation, resourceType, id)
+
+	if c.Mode == CrashExit {
+		// The message is written to stderr, so it still ends up in the
+		// Terraform logs even though we don't panic.
+		_, _ = fmt.Fprintln(os.Stderr, msg)
+
+		code := c.ExitCode
+		if code == 0 {
+			code = 1
+		}
+		exit(code)
+		return
+	}
+	panic(msg)
+}
@@ -0,0 +1,90 @@
+// Copyright IBM Corp. 2022, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package behaviour
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestCrash_Trigger(t *testing.T) {
+	t.Run("panic", func(t *testing.T) {
+		defer func() {
+			r := recover()
+			if r == nil {
+				t.Fatalf("expected Trigger() to panic")
+			}
+			if !strings.Contains(r.(string), "create of tfcoremock_simple_resource with id 'one'") {
+				t.Fatalf("unexpected panic message: %v", r)
+			}
+		}()
+		Crash{}.Trigger(Create, "tfcoremock_simple_resource", "one")
+	})
+
+	t.Run("exit", func(t *testing.T) {
+		code := -1
+		exit = func(c int) {
+			code = c
+		}
+		t.Cleanup(func() {
+			exit = os.Exit
+		})
+
+		Crash{Mode: CrashExit, ExitCode: 3}.Trigger(Create, "tfcoremock_simple_resource", "one")
+		if code != 3 {
+			t.Fatalf("expected exit code 3 but found %d", code)
+		}
+	})
+
+	t.Run("exit_default", func(t *testing.T) {
+		code := -1
+		exit = func(c int) {
+			code = c
+		}
+		t.Cleanup(func() {
+			exit = os.Exit
+		})
+
+		crash := Crash{Mode: CrashExit, ExitCode: 0}
+		if err := crash.Validate(); err != nil {
+			t.Fatalf("unexpected error in Validate(): %v", err)
+		}
+		crash.Trigger(Create, "tfcoremock_simple_resource", "one")
+		if code != 1 {
+			t.Fatalf("expected exit code 1 but found %d", code)
+		}
+	})
+}
+
+func TestCrash_Validate(t *testing.T) {
+	testCases := []struct {
+		TestCase string
+		Crash    Crash
+	}{
+		{
+			TestCase: "invalid_mode",
+			Crash:    Crash{Mode: "explode"},
+		},
+		{
+			TestCase: "exit_code_with_panic",
+			Crash:    Crash{ExitCode: 1},
+		},
+		{
+			TestCase: "exit_code_out_of_range",
+			Crash:    Crash{Mode: CrashExit, ExitCode: 255},
+		},
+		{
+			TestCase: "invalid_schedule",
+			Crash:    Crash{Schedule: Schedule{OnCall: 1, FirstCalls: 1}},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.TestCase, func(t *testing.T) {
+			if err := testCase.Crash.Validate(); err == nil {
+				t.Fatalf("expected error in Validate() but found none")
+			}
+		})
+	}
+}
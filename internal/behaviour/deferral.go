@@ -0,0 +1,113 @@
+// Copyright IBM Corp. 2022, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package behaviour
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// DeferralReason is the reason given to Terraform when an operation is
+// deferred.
+type DeferralReason string
+
+const (
+	DeferralResourceConfigUnknown DeferralReason = "resource_config_unknown"
+	DeferralProviderConfigUnknown DeferralReason = "provider_config_unknown"
+	DeferralAbsentPrereq          DeferralReason = "absent_prereq"
+)
+
+// deferrableOperations contains the operations that Terraform allows providers
+// to defer. Destroy plans are missing, as Terraform can't defer them.
+var deferrableOperations = []Operation{Plan, PlanCreate, PlanUpdate, PlanReplace, Read, Import, ReadDataSource}
+
+// Deferral forces the targeted operations to be deferred by Terraform.
+//
+// Deferrals that don't list any operations only apply to plans, so refreshes,
+// imports and data sources are only deferred when they are targeted
+// explicitly. Reason defaults to DeferralResourceConfigUnknown.
+//
+// If WhenUnknown is set, or UnknownAttributes is not empty, then the deferral
+// only applies when the configuration contains unknown values. This mimics
+// providers that can't plan until their inputs are known.
+type Deferral struct {
+	Target
+
+	Reason            DeferralReason `json:"reason,omitempty"`
+	WhenUnknown       bool           `json:"when_unknown,omitempty"`
+	UnknownAttributes []string       `json:"unknown_attributes,omitempty"`
+}
+
+// Validate checks the target and reason of the deferral are valid, and that
+// it only targets operations that can be deferred.
+func (d Deferral) Validate() error {
+	if err := d.Target.Validate(); err != nil {
+		return err
+	}
+
+	for _, operation := range d.Operations {
+		if !slices.Contains(deferrableOperations, operation) {
+			return fmt.Errorf("the %s operation cannot be deferred", operation)
+		}
+	}
+
+	switch d.Reason {
+	case "", DeferralResourceConfigUnknown, DeferralProviderConfigUnknown, DeferralAbsentPrereq:
+	default:
+		return fmt.Errorf("unrecognized deferral reason '%s'", d.Reason)
+	}
+
+	if d.WhenUnknown || len(d.UnknownAttributes) > 0 {
+		// Only plans and data sources are given the configuration, so they
+		// are the only operations that know if it contains unknown values.
+		for _, operation := range d.Operations {
+			if !operation.IsPlan() && operation != ReadDataSource {
+				return fmt.Errorf("deferrals for unknown values cannot target the %s operation", operation)
+			}
+		}
+	}
+	for _, attribute := range d.UnknownAttributes {
+		if len(attribute) == 0 {
+			return errors.New("unknown_attributes cannot contain empty names")
+		}
+	}
+	return nil
+}
+
+// Matches returns true if the deferral applies to the given operation on the
+// specified resource.
+func (d Deferral) Matches(operation Operation, resourceType string, id string) bool {
+	if len(d.Operations) == 0 && !operation.IsPlan() {
+		return false
+	}
+	return d.Target.Matches(operation, resourceType, id)
+}
+
+// Defers returns true if the deferral should be applied to an operation whose
+// configuration has unknown values in the given attributes.
+func (d Deferral) Defers(unknown []string) bool {
+	if len(d.UnknownAttributes) > 0 {
+		for _, attribute := range d.UnknownAttributes {
+			if slices.Contains(unknown, attribute) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if d.WhenUnknown {
+		return len(unknown) > 0
+	}
+	return true
+}
+
+// GetReason returns the reason for the deferral, applying the default if one
+// hasn't been set.
+func (d Deferral) GetReason() DeferralReason {
+	if len(d.Reason) == 0 {
+		return DeferralResourceConfigUnknown
+	}
+	return d.Reason
+}
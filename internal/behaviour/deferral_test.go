@@ -0,0 +1,135 @@
+// Copyright IBM Corp. 2022, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package behaviour
+
+import "testing"
+
+func TestDeferral_Matches(t *testing.T) {
+	testCases := []struct {
+		TestCase  string
+		Deferral  Deferral
+		Operation Operation
+		Expected  bool
+	}{
+		{
+			TestCase:  "no_operations_plan",
+			Deferral:  Deferral{},
+			Operation: PlanUpdate,
+			Expected:  true,
+		},
+		{
+			TestCase:  "no_operations_read",
+			Deferral:  Deferral{},
+			Operation: Read,
+			Expected:  false,
+		},
+		{
+			TestCase:  "explicit_read",
+			Deferral:  Deferral{Target: Target{Operations: []Operation{Read}}},
+			Operation: Read,
+			Expected:  true,
+		},
+		{
+			TestCase:  "explicit_read_plan",
+			Deferral:  Deferral{Target: Target{Operations: []Operation{Read}}},
+			Operation: PlanCreate,
+			Expected:  false,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.TestCase, func(t *testing.T) {
+			actual := testCase.Deferral.Matches(testCase.Operation, "tfcoremock_simple_resource", "id")
+			if actual != testCase.Expected {
+				t.Fatalf("expected %t but found %t", testCase.Expected, actual)
+			}
+		})
+	}
+}
+
+func TestDeferral_Validate(t *testing.T) {
+	testCases := []struct {
+		TestCase string
+		Deferral Deferral
+	}{
+		{
+			TestCase: "invalid_target",
+			Deferral: Deferral{Target: Target{Match: "fuzzy"}},
+		},
+		{
+			TestCase: "create",
+			Deferral: Deferral{Target: Target{Operations: []Operation{Create}}},
+		},
+		{
+			TestCase: "plan_delete",
+			Deferral: Deferral{Target: Target{Operations: []Operation{PlanDelete}}},
+		},
+		{
+			TestCase: "invalid_reason",
+			Deferral: Deferral{Reason: "because"},
+		},
+		{
+			TestCase: "unknown_read",
+			Deferral: Deferral{Target: Target{Operations: []Operation{Read}}, WhenUnknown: true},
+		},
+		{
+			TestCase: "empty_unknown_attribute",
+			Deferral: Deferral{UnknownAttributes: []string{""}},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.TestCase, func(t *testing.T) {
+			if err := testCase.Deferral.Validate(); err == nil {
+				t.Fatalf("expected error in Validate() but found none")
+			}
+		})
+	}
+}
+
+func TestDeferral_Defers(t *testing.T) {
+	testCases := []struct {
+		TestCase string
+		Deferral Deferral
+		Unknown  []string
+		Expected bool
+	}{
+		{
+			TestCase: "always",
+			Deferral: Deferral{},
+			Unknown:  nil,
+			Expected: true,
+		},
+		{
+			TestCase: "when_unknown_known",
+			Deferral: Deferral{WhenUnknown: true},
+			Unknown:  nil,
+			Expected: false,
+		},
+		{
+			TestCase: "when_unknown_unknown",
+			Deferral: Deferral{WhenUnknown: true},
+			Unknown:  []string{"string"},
+			Expected: true,
+		},
+		{
+			TestCase: "unknown_attributes_match",
+			Deferral: Deferral{UnknownAttributes: []string{"id", "integer"}},
+			Unknown:  []string{"integer"},
+			Expected: true,
+		},
+		{
+			TestCase: "unknown_attributes_mismatch",
+			Deferral: Deferral{UnknownAttributes: []string{"id"}},
+			Unknown:  []string{"integer"},
+			Expected: false,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.TestCase, func(t *testing.T) {
+			actual := testCase.Deferral.Defers(testCase.Unknown)
+			if actual != testCase.Expected {
+				t.Fatalf("expected %t but found %t", testCase.Expected, actual)
+			}
+		})
+	}
+}
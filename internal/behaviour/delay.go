@@ -0,0 +1,61 @@
+// Copyright IBM Corp. 2022, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package behaviour
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Delay slows down the targeted operations, according to its schedule.
+//
+// The delay is abandoned as soon as the operation is cancelled, so it can be
+// used to test how Terraform handles interrupts.
+type Delay struct {
+	Target
+	Schedule
+
+	Duration string `json:"duration"`
+}
+
+// Validate checks the target, schedule and duration of the delay are all
+// valid.
+func (d Delay) Validate() error {
+	if err := d.Target.Validate(); err != nil {
+		return err
+	}
+	if err := d.Schedule.Validate(); err != nil {
+		return err
+	}
+
+	duration, err := time.ParseDuration(d.Duration)
+	if err != nil {
+		return fmt.Errorf("invalid duration '%s': %w", d.Duration, err)
+	}
+	if duration < 0 {
+		return errors.New("duration cannot be negative")
+	}
+	return nil
+}
+
+// Wait blocks until the delay has elapsed, or returns an error as soon as the
+// context is cancelled.
+func (d Delay) Wait(ctx context.Context) error {
+	duration, err := time.ParseDuration(d.Duration)
+	if err != nil {
+		return fmt.Errorf("invalid duration '%s': %w", d.Duration, err)
+	}
+
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
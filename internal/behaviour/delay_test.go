@@ -0,0 +1,61 @@
+// Copyright IBM Corp. 2022, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package behaviour
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDelay_Wait(t *testing.T) {
+	delay := Delay{Duration: "1m"}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	start := time.Now()
+	if err := delay.Wait(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled but found %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("expected cancelled delay to return immediately but took %s", elapsed)
+	}
+
+	if err := (Delay{Duration: "1ms"}).Wait(context.Background()); err != nil {
+		t.Fatalf("expected no error but found %v", err)
+	}
+}
+
+func TestDelay_Validate(t *testing.T) {
+	testCases := []struct {
+		TestCase string
+		Delay    Delay
+	}{
+		{
+			TestCase: "missing_duration",
+			Delay:    Delay{},
+		},
+		{
+			TestCase: "invalid_duration",
+			Delay:    Delay{Duration: "soon"},
+		},
+		{
+			TestCase: "negative_duration",
+			Delay:    Delay{Duration: "-1s"},
+		},
+		{
+			TestCase: "invalid_target",
+			Delay:    Delay{Target: Target{Match: "fuzzy"}, Duration: "1s"},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.TestCase, func(t *testing.T) {
+			if err := testCase.Delay.Validate(); err == nil {
+				t.Fatalf("expected error in Validate() but found none")
+			}
+		})
+	}
+}
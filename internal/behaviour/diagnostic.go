@@ -0,0 +1,141 @@
+// Copyright IBM Corp. 2022, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package behaviour
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// Severity is the severity of the diagnostic raised by a failure.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Diagnostic customises the diagnostic that is returned when a failure is
+// triggered.
+//
+// Any fields that are left empty fall back to the defaults for the operation
+// that failed. A warning does not stop the operation from succeeding.
+type Diagnostic struct {
+	Severity  Severity `json:"severity,omitempty"`
+	Summary   string   `json:"summary,omitempty"`
+	Detail    string   `json:"detail,omitempty"`
+	Attribute string   `json:"attribute,omitempty"`
+}
+
+// Validate checks the severity and the attribute path are valid.
+func (d Diagnostic) Validate() error {
+	switch d.Severity {
+	case "", SeverityError, SeverityWarning:
+	default:
+		return fmt.Errorf("unrecognized severity '%s'", d.Severity)
+	}
+
+	if _, err := ParseAttributePath(d.Attribute); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ToDiagnostic converts the Diagnostic into a Terraform diagnostic, using the
+// given summary and detail if they were not overridden.
+func (d Diagnostic) ToDiagnostic(summary string, detail string) diag.Diagnostic {
+	if len(d.Summary) > 0 {
+		summary = d.Summary
+	}
+	if len(d.Detail) > 0 {
+		detail = d.Detail
+	}
+
+	// We validated the path when the failure was loaded, so we can ignore any
+	// errors here.
+	attribute, _ := ParseAttributePath(d.Attribute)
+
+	switch {
+	case d.Severity == SeverityWarning && attribute != nil:
+		return diag.NewAttributeWarningDiagnostic(*attribute, summary, detail)
+	case d.Severity == SeverityWarning:
+		return diag.NewWarningDiagnostic(summary, detail)
+	case attribute != nil:
+		return diag.NewAttributeErrorDiagnostic(*attribute, summary, detail)
+	default:
+		return diag.NewErrorDiagnostic(summary, detail)
+	}
+}
+
+// ParseAttributePath converts a string such as `list[0].object.attribute` or
+// `map["key"]` into a path.Path.
+//
+// It returns nil if the string is empty.
+func ParseAttributePath(attribute string) (*path.Path, error) {
+	if len(attribute) == 0 {
+		return nil, nil
+	}
+
+	var current path.Path
+	started := false
+
+	remaining := attribute
+	for len(remaining) > 0 {
+		switch {
+		case strings.HasPrefix(remaining, "["):
+			if !started {
+				return nil, fmt.Errorf("invalid attribute path '%s': must start with an attribute name", attribute)
+			}
+
+			end := strings.Index(remaining, "]")
+			if end < 0 {
+				return nil, fmt.Errorf("invalid attribute path '%s': missing closing bracket", attribute)
+			}
+			key := remaining[1:end]
+			remaining = remaining[end+1:]
+
+			if unquoted, err := strconv.Unquote(key); err == nil {
+				current = current.AtMapKey(unquoted)
+				continue
+			}
+
+			index, err := strconv.ParseInt(key, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid attribute path '%s': '%s' is not a list index or a quoted map key", attribute, key)
+			}
+			current = current.AtListIndex(int(index))
+		default:
+			if started {
+				if !strings.HasPrefix(remaining, ".") {
+					return nil, fmt.Errorf("invalid attribute path '%s': expected '.' or '['", attribute)
+				}
+				remaining = remaining[1:]
+			}
+
+			end := strings.IndexAny(remaining, ".[")
+			if end < 0 {
+				end = len(remaining)
+			}
+			name := remaining[:end]
+			remaining = remaining[end:]
+
+			if len(name) == 0 {
+				return nil, fmt.Errorf("invalid attribute path '%s': empty attribute name", attribute)
+			}
+
+			if !started {
+				current = path.Root(name)
+				started = true
+				continue
+			}
+			current = current.AtName(name)
+		}
+	}
+
+	return &current, nil
+}
@@ -0,0 +1,82 @@
+// Copyright IBM Corp. 2022, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package behaviour
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+func TestParseAttributePath(t *testing.T) {
+	testCases := []struct {
+		TestCase  string
+		Attribute string
+		Expected  *path.Path
+		Error     bool
+	}{
+		{
+			TestCase:  "empty",
+			Attribute: "",
+			Expected:  nil,
+		},
+		{
+			TestCase:  "root",
+			Attribute: "string",
+			Expected:  pathPtr(path.Root("string")),
+		},
+		{
+			TestCase:  "nested",
+			Attribute: "list[0].object.string",
+			Expected:  pathPtr(path.Root("list").AtListIndex(0).AtName("object").AtName("string")),
+		},
+		{
+			TestCase:  "map_key",
+			Attribute: `map["key"].string`,
+			Expected:  pathPtr(path.Root("map").AtMapKey("key").AtName("string")),
+		},
+		{
+			TestCase:  "leading_index",
+			Attribute: "[0]",
+			Error:     true,
+		},
+		{
+			TestCase:  "unclosed_index",
+			Attribute: "list[0",
+			Error:     true,
+		},
+		{
+			TestCase:  "invalid_index",
+			Attribute: "list[zero]",
+			Error:     true,
+		},
+		{
+			TestCase:  "empty_name",
+			Attribute: "object..string",
+			Error:     true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.TestCase, func(t *testing.T) {
+			actual, err := ParseAttributePath(testCase.Attribute)
+			if testCase.Error {
+				if err == nil {
+					t.Fatalf("expected error but found none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("found unexpected error: %v", err)
+			}
+
+			if (actual == nil) != (testCase.Expected == nil) || (actual != nil && !actual.Equal(*testCase.Expected)) {
+				t.Fatalf("expected %v but found %v", testCase.Expected, actual)
+			}
+		})
+	}
+}
+
+func pathPtr(p path.Path) *path.Path {
+	return &p
+}
@@ -0,0 +1,85 @@
+// Copyright IBM Corp. 2022, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package behaviour
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+
+	"github.com/hashicorp/terraform-provider-tfcoremock/internal/data"
+)
+
+// Drift changes the targeted resources when they are refreshed, according to
+// its schedule, as if they had been changed outside of Terraform.
+//
+// A drift either removes the resource entirely, or replaces the values of the
+// given top-level attributes. Values are in the same JSON format the resources
+// are written in. The changes are written back to the resource, so later
+// refreshes see them as well.
+//
+// Drifts only apply to the read operation, and count refreshes separately to
+// any failures, delays or crashes. Refreshes that fail or are deferred are not
+// counted.
+type Drift struct {
+	Target
+	Schedule
+
+	Remove bool                  `json:"remove,omitempty"`
+	Values map[string]data.Value `json:"values,omitempty"`
+}
+
+// Validate checks the target and schedule of the drift are valid, and that it
+// either removes the resource or changes its values.
+func (d Drift) Validate() error {
+	if err := d.Target.Validate(); err != nil {
+		return err
+	}
+	if err := d.Schedule.Validate(); err != nil {
+		return err
+	}
+
+	for _, operation := range d.Operations {
+		if operation != Read {
+			return fmt.Errorf("drifts can only target the read operation, not %s", operation)
+		}
+	}
+
+	if d.Remove == (len(d.Values) > 0) {
+		return errors.New("exactly one of remove and values must be set")
+	}
+	for name := range d.Values {
+		if len(name) == 0 {
+			return errors.New("values cannot contain an empty attribute name")
+		}
+		if name == "id" {
+			return errors.New("drifts cannot change the id attribute")
+		}
+	}
+	return nil
+}
+
+// Matches returns true if the drift applies to the given operation on the
+// specified resource. Drifts only ever apply to the read operation.
+func (d Drift) Matches(operation Operation, resourceType string, id string) bool {
+	return operation == Read && d.Target.Matches(operation, resourceType, id)
+}
+
+// Apply changes the values of the resource, and returns false if the resource
+// should be removed instead.
+func (d Drift) Apply(resource *data.Resource) bool {
+	if d.Remove {
+		return false
+	}
+
+	// We copy the values, so the drift doesn't change any resource that
+	// shares them.
+	values := maps.Clone(resource.Values)
+	if values == nil {
+		values = make(map[string]data.Value, len(d.Values))
+	}
+	maps.Copy(values, d.Values)
+	resource.Values = values
+	return true
+}
@@ -0,0 +1,92 @@
+// Copyright IBM Corp. 2022, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package behaviour
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-tfcoremock/internal/data"
+)
+
+func TestDrift_Validate(t *testing.T) {
+	testCases := []struct {
+		TestCase string
+		Drift    Drift
+	}{
+		{
+			TestCase: "nothing_to_do",
+			Drift:    Drift{},
+		},
+		{
+			TestCase: "remove_and_values",
+			Drift:    Drift{Remove: true, Values: map[string]data.Value{"string": {}}},
+		},
+		{
+			TestCase: "id",
+			Drift:    Drift{Values: map[string]data.Value{"id": {}}},
+		},
+		{
+			TestCase: "not_read",
+			Drift:    Drift{Target: Target{Operations: []Operation{Update}}, Remove: true},
+		},
+		{
+			TestCase: "invalid_schedule",
+			Drift:    Drift{Schedule: Schedule{OnCall: 1, FirstCalls: 1}, Remove: true},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.TestCase, func(t *testing.T) {
+			if err := testCase.Drift.Validate(); err == nil {
+				t.Fatalf("expected error in Validate() but found none")
+			}
+		})
+	}
+}
+
+func TestBehaviours_Drift(t *testing.T) {
+	drifted := "drifted"
+	behaviours := Behaviours{
+		Drifts: []Drift{
+			{
+				Target:   Target{ID: "one"},
+				Schedule: Schedule{OnCall: 2},
+				Values: map[string]data.Value{
+					"string": {String: &drifted},
+				},
+			},
+		},
+	}
+
+	counter := &MemoryCounter{}
+	for call, expected := range []bool{false, true, false} {
+		drift, err := behaviours.Drift("tfcoremock_simple_resource", "one", counter)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if (drift != nil) != expected {
+			t.Fatalf("expected drift on call %d to be %t", call+1, expected)
+		}
+	}
+
+	if drift, _ := behaviours.Drift("tfcoremock_simple_resource", "two", counter); drift != nil {
+		t.Fatalf("expected no drift for unmatched resource")
+	}
+
+	original := "original"
+	resource := &data.Resource{
+		Values: map[string]data.Value{
+			"string": {String: &original},
+		},
+	}
+	values := resource.Values
+	if !behaviours.Drifts[0].Apply(resource) {
+		t.Fatalf("expected Apply() to keep the resource")
+	}
+	if *resource.Values["string"].String != drifted {
+		t.Fatalf("expected drifted value but found %s", *resource.Values["string"].String)
+	}
+	if *values["string"].String != original {
+		t.Fatalf("expected original values to be unchanged")
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright IBM Corp. 2022, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package behaviour
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// InconsistencyMode is what reads return while a resource is inconsistent.
+type InconsistencyMode string
+
+const (
+	InconsistencyStale    InconsistencyMode = "stale"
+	InconsistencyNotFound InconsistencyMode = "not_found"
+)
+
+// Inconsistency makes reads of the targeted resources eventually consistent,
+// as if the resources were held by a remote API that takes time to catch up
+// with its writes.
+//
+// After every targeted create or update, reads return the previous revision of
+// the resource, or report the resource as missing, until Reads reads have been
+// made or Duration has passed, whichever happens first. A stale read straight
+// after a create reports the resource as missing, as there is no previous
+// revision.
+//
+// If ReadAfterWrite is set, the create or update reads the resource back
+// before returning, so it returns the inconsistent result to Terraform.
+type Inconsistency struct {
+	Target
+
+	Reads          int64             `json:"reads,omitempty"`
+	Duration       string            `json:"duration,omitempty"`
+	Mode           InconsistencyMode `json:"mode,omitempty"`
+	ReadAfterWrite bool              `json:"read_after_write,omitempty"`
+}
+
+// Validate checks the target, limits and mode of the inconsistency are all
+// valid.
+func (i Inconsistency) Validate() error {
+	if err := i.Target.Validate(); err != nil {
+		return err
+	}
+
+	for _, operation := range i.Operations {
+		if operation != Create && operation != Update {
+			return fmt.Errorf("inconsistencies can only target the create and update operations, not %s", operation)
+		}
+	}
+
+	if i.Reads < 0 {
+		return errors.New("reads cannot be negative")
+	}
+	if i.Reads == 0 && len(i.Duration) == 0 {
+		return errors.New("at least one of reads and duration must be set")
+	}
+	if _, err := i.GetDuration(); err != nil {
+		return err
+	}
+
+	switch i.Mode {
+	case "", InconsistencyStale, InconsistencyNotFound:
+	default:
+		return fmt.Errorf("unrecognized inconsistency mode '%s'", i.Mode)
+	}
+	return nil
+}
+
+// Matches returns true if the inconsistency applies after the given operation
+// on the specified resource. Inconsistencies only ever apply after creates and
+// updates.
+func (i Inconsistency) Matches(operation Operation, resourceType string, id string) bool {
+	if operation != Create && operation != Update {
+		return false
+	}
+	return i.Target.Matches(operation, resourceType, id)
+}
+
+// GetDuration returns how long reads are inconsistent for after a write, or
+// zero if reads are only limited by their number.
+func (i Inconsistency) GetDuration() (time.Duration, error) {
+	if len(i.Duration) == 0 {
+		return 0, nil
+	}
+
+	duration, err := time.ParseDuration(i.Duration)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration '%s': %w", i.Duration, err)
+	}
+	if duration < 0 {
+		return 0, errors.New("duration cannot be negative")
+	}
+	return duration, nil
+}
+
+// GetMode returns the mode of the inconsistency, applying the default.
+func (i Inconsistency) GetMode() InconsistencyMode {
+	if len(i.Mode) == 0 {
+		return InconsistencyStale
+	}
+	return i.Mode
+}
@@ -0,0 +1,79 @@
+// Copyright IBM Corp. 2022, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package behaviour
+
+import (
+	"testing"
+)
+
+func TestInconsistency_Validate(t *testing.T) {
+	testCases := []struct {
+		TestCase      string
+		Inconsistency Inconsistency
+	}{
+		{
+			TestCase:      "no_limits",
+			Inconsistency: Inconsistency{},
+		},
+		{
+			TestCase:      "negative_reads",
+			Inconsistency: Inconsistency{Reads: -1},
+		},
+		{
+			TestCase:      "invalid_duration",
+			Inconsistency: Inconsistency{Duration: "soon"},
+		},
+		{
+			TestCase:      "negative_duration",
+			Inconsistency: Inconsistency{Duration: "-1s"},
+		},
+		{
+			TestCase:      "invalid_mode",
+			Inconsistency: Inconsistency{Reads: 1, Mode: "missing"},
+		},
+		{
+			TestCase:      "not_write",
+			Inconsistency: Inconsistency{Target: Target{Operations: []Operation{Read}}, Reads: 1},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.TestCase, func(t *testing.T) {
+			if err := testCase.Inconsistency.Validate(); err == nil {
+				t.Fatalf("expected error in Validate() but found none")
+			}
+		})
+	}
+}
+
+func TestBehaviours_Inconsistency(t *testing.T) {
+	behaviours := Behaviours{
+		Inconsistencies: []Inconsistency{
+			{
+				Target: Target{ID: "one", Operations: []Operation{Update}},
+				Reads:  1,
+			},
+			{
+				Target: Target{ResourceType: "tfcoremock_simple_resource"},
+				Reads:  2,
+				Mode:   InconsistencyNotFound,
+			},
+		},
+	}
+	if err := behaviours.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if inconsistency := behaviours.Inconsistency(Update, "tfcoremock_complex_resource", "one"); inconsistency == nil || inconsistency.Reads != 1 {
+		t.Fatalf("expected the first inconsistency to match")
+	}
+	if inconsistency := behaviours.Inconsistency(Create, "tfcoremock_simple_resource", "one"); inconsistency == nil || inconsistency.GetMode() != InconsistencyNotFound {
+		t.Fatalf("expected the second inconsistency to match")
+	}
+	if inconsistency := behaviours.Inconsistency(Read, "tfcoremock_simple_resource", "one"); inconsistency != nil {
+		t.Fatalf("expected no inconsistency for reads")
+	}
+	if inconsistency := behaviours.Inconsistency(Create, "tfcoremock_complex_resource", "one"); inconsistency != nil {
+		t.Fatalf("expected no inconsistency for unmatched resource")
+	}
+}
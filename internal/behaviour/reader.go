@@ -0,0 +1,51 @@
+// Copyright IBM Corp. 2022, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package behaviour
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Reader loads behaviours from outside the provider configuration, so they can
+// change between operations without changing the configuration itself.
+type Reader interface {
+	Read() (Behaviours, error)
+}
+
+var _ Reader = FileReader{}
+
+// FileReader reads behaviours from a JSON file every time Read is called.
+//
+// A missing file is treated as an empty set of behaviours, so behaviours can
+// be switched on and off by creating and deleting the file.
+type FileReader struct {
+	File string
+}
+
+func (r FileReader) Read() (Behaviours, error) {
+	data, err := os.ReadFile(r.File)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return Behaviours{}, nil
+		}
+		return Behaviours{}, fmt.Errorf("failed to read %s: %w", r.File, err)
+	}
+
+	var behaviours Behaviours
+	if len(bytes.TrimSpace(data)) > 0 {
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&behaviours); err != nil {
+			return Behaviours{}, fmt.Errorf("failed to unmarshal %s: %w", r.File, err)
+		}
+	}
+
+	if err := behaviours.Validate(); err != nil {
+		return Behaviours{}, fmt.Errorf("invalid behaviours in %s: %w", r.File, err)
+	}
+	return behaviours, nil
+}
@@ -0,0 +1,70 @@
+// Copyright IBM Corp. 2022, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package behaviour
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileReader_Read(t *testing.T) {
+	testCases := []struct {
+		TestCase string
+		Contents *string
+		Failures int
+		Error    bool
+	}{
+		{
+			TestCase: "missing_file",
+		},
+		{
+			TestCase: "empty_file",
+			Contents: ptr(""),
+		},
+		{
+			TestCase: "failures",
+			Contents: ptr(`{"failures": [{"operations": ["create"], "id": "one"}, {"operations": ["read"]}]}`),
+			Failures: 2,
+		},
+		{
+			TestCase: "unknown_field",
+			Contents: ptr(`{"failures": [{"operation": ["create"]}]}`),
+			Error:    true,
+		},
+		{
+			TestCase: "invalid_behaviour",
+			Contents: ptr(`{"delays": [{"duration": "soon"}]}`),
+			Error:    true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.TestCase, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "faults.json")
+			if testCase.Contents != nil {
+				if err := os.WriteFile(file, []byte(*testCase.Contents), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			behaviours, err := FileReader{File: file}.Read()
+			if testCase.Error {
+				if err == nil {
+					t.Fatalf("expected error in Read() but found none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("found unexpected error in Read(): %v", err)
+			}
+			if len(behaviours.Failures) != testCase.Failures {
+				t.Fatalf("expected %d failures but found %d", testCase.Failures, len(behaviours.Failures))
+			}
+		})
+	}
+}
+
+func ptr[T any](value T) *T {
+	return &value
+}
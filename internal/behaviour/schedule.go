@@ -0,0 +1,72 @@
+// Copyright IBM Corp. 2022, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package behaviour
+
+import (
+	"errors"
+	"hash/fnv"
+	"math/rand/v2"
+)
+
+// Schedule decides which invocations of a targeted operation actually trigger
+// a behaviour.
+//
+// Invocations are counted from 1 for each combination of operation, resource
+// type and resource ID. A zero Schedule triggers on every invocation.
+type Schedule struct {
+	// OnCall triggers the behaviour only on the Nth invocation.
+	OnCall int64 `json:"on_call,omitempty"`
+
+	// FirstCalls triggers the behaviour on the first N invocations only.
+	FirstCalls int64 `json:"first_calls,omitempty"`
+
+	// Probability triggers the behaviour randomly with the given probability.
+	// The random source is derived from Seed, the invocation being made, and
+	// the resource being targeted so the same sequence of invocations will
+	// always trigger in the same way.
+	Probability *float64 `json:"probability,omitempty"`
+	Seed        int64    `json:"seed,omitempty"`
+}
+
+// IsZero returns true if the schedule triggers on every invocation, and so
+// doesn't need the invocations to be counted.
+func (s Schedule) IsZero() bool {
+	return s.OnCall == 0 && s.FirstCalls == 0 && s.Probability == nil
+}
+
+// Validate checks the schedule doesn't contain conflicting or out of range
+// values.
+func (s Schedule) Validate() error {
+	if s.OnCall < 0 || s.FirstCalls < 0 {
+		return errors.New("on_call and first_calls cannot be negative")
+	}
+	if s.OnCall > 0 && s.FirstCalls > 0 {
+		return errors.New("only one of on_call and first_calls can be set")
+	}
+	if s.Probability != nil && (*s.Probability < 0 || *s.Probability > 1) {
+		return errors.New("probability must be between 0 and 1")
+	}
+	return nil
+}
+
+// Triggers returns true if the behaviour should be applied to the given
+// invocation of the operation identified by key.
+func (s Schedule) Triggers(key string, call int64) bool {
+	if s.OnCall > 0 && call != s.OnCall {
+		return false
+	}
+
+	if s.FirstCalls > 0 && call > s.FirstCalls {
+		return false
+	}
+
+	if s.Probability != nil {
+		hash := fnv.New64a()
+		_, _ = hash.Write([]byte(key))
+		random := rand.New(rand.NewPCG(uint64(s.Seed), hash.Sum64()^uint64(call)))
+		return random.Float64() < *s.Probability
+	}
+
+	return true
+}
@@ -0,0 +1,50 @@
+// Copyright IBM Corp. 2022, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/hashicorp/terraform-provider-tfcoremock/internal/data"
+)
+
+var _ Client = Eventual{}
+
+// Eventual wraps another client, and makes reads of resources eventually
+// consistent. While a revision is recorded for a resource, reads return the
+// previous version of the resource, or report it as missing, instead of
+// reading the latest version from the wrapped client.
+type Eventual struct {
+	Client
+	Revisions Revisions
+}
+
+func (eventual Eventual) ReadResource(ctx context.Context, id string) (*data.Resource, error) {
+	revision, err := eventual.Revisions.Consume(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if revision == nil {
+		return eventual.Client.ReadResource(ctx, id)
+	}
+
+	tflog.Trace(ctx, "Eventual.ReadResource: returning previous revision")
+	if revision.Previous == nil {
+		return nil, os.ErrNotExist
+	}
+	return revision.Previous, nil
+}
+
+func (eventual Eventual) DeleteResource(ctx context.Context, id string) error {
+	if err := eventual.Client.DeleteResource(ctx, id); err != nil {
+		return err
+	}
+
+	// Reads of a deleted resource shouldn't see any previous version.
+	return eventual.Revisions.Forget(id)
+}
@@ -0,0 +1,190 @@
+// Copyright IBM Corp. 2022, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-provider-tfcoremock/internal/data"
+)
+
+// Revision is the version of a resource that reads return for a while after
+// the resource has been written, instead of the latest version.
+type Revision struct {
+	// Previous is the version reads return. If it is nil, reads report the
+	// resource as missing.
+	Previous *data.Resource `json:"previous,omitempty"`
+
+	// Reads is the number of reads left that return the previous version.
+	// Zero means the number of reads is unlimited, and only Until applies.
+	Reads int64 `json:"reads,omitempty"`
+
+	// Until is the time at which reads start returning the latest version. If
+	// it is nil, only Reads applies.
+	Until *time.Time `json:"until,omitempty"`
+}
+
+// Revisions records the previous revisions of resources, so that reads can be
+// made eventually consistent.
+type Revisions interface {
+	// Record sets the revision reads of the given resource return.
+	Record(id string, revision Revision) error
+
+	// Consume returns the revision the next read of the given resource should
+	// return, or nil if the read should return the latest version.
+	Consume(id string) (*Revision, error)
+
+	// Forget removes any revision recorded for the given resource.
+	Forget(id string) error
+}
+
+var _ Revisions = &FileRevisions{}
+var _ Revisions = &MemoryRevisions{}
+
+// FileRevisions persists the revisions into a JSON file, so they are shared
+// between subsequent runs of the provider.
+type FileRevisions struct {
+	File string
+
+	mutex sync.Mutex
+}
+
+func (revisions *FileRevisions) Record(id string, revision Revision) error {
+	return revisions.update(func(all map[string]Revision) bool {
+		all[id] = revision
+		return true
+	})
+}
+
+func (revisions *FileRevisions) Consume(id string) (*Revision, error) {
+	var revision *Revision
+	err := revisions.update(func(all map[string]Revision) bool {
+		if _, ok := all[id]; !ok {
+			return false
+		}
+		revision = consume(all, id, time.Now())
+		return true
+	})
+	return revision, err
+}
+
+func (revisions *FileRevisions) Forget(id string) error {
+	return revisions.update(func(all map[string]Revision) bool {
+		if _, ok := all[id]; !ok {
+			return false
+		}
+		delete(all, id)
+		return true
+	})
+}
+
+// update applies fn to the revisions in the file, and writes them back if fn
+// reports that it changed them.
+func (revisions *FileRevisions) update(fn func(all map[string]Revision) bool) error {
+	revisions.mutex.Lock()
+	defer revisions.mutex.Unlock()
+
+	all := make(map[string]Revision)
+
+	jsonData, err := os.ReadFile(revisions.File)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if len(jsonData) > 0 {
+		if err := json.Unmarshal(jsonData, &all); err != nil {
+			return fmt.Errorf("failed to unmarshal %s: %w", revisions.File, err)
+		}
+	}
+
+	if !fn(all) {
+		return nil
+	}
+
+	if len(all) == 0 {
+		// We remove the file once nothing is inconsistent, so it doesn't
+		// linger after the resources have gone.
+		if err := os.Remove(revisions.File); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+
+	if jsonData, err = json.MarshalIndent(all, "", "  "); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(revisions.File), 0700); err != nil {
+		return err
+	}
+
+	return os.WriteFile(revisions.File, jsonData, 0644)
+}
+
+// MemoryRevisions holds the revisions in memory, so they are forgotten every
+// time the provider is restarted.
+type MemoryRevisions struct {
+	revisions map[string]Revision
+	mutex     sync.Mutex
+}
+
+func (revisions *MemoryRevisions) Record(id string, revision Revision) error {
+	revisions.mutex.Lock()
+	defer revisions.mutex.Unlock()
+
+	if revisions.revisions == nil {
+		revisions.revisions = make(map[string]Revision)
+	}
+	revisions.revisions[id] = revision
+	return nil
+}
+
+func (revisions *MemoryRevisions) Consume(id string) (*Revision, error) {
+	revisions.mutex.Lock()
+	defer revisions.mutex.Unlock()
+
+	if revisions.revisions == nil {
+		return nil, nil
+	}
+	return consume(revisions.revisions, id, time.Now()), nil
+}
+
+func (revisions *MemoryRevisions) Forget(id string) error {
+	revisions.mutex.Lock()
+	defer revisions.mutex.Unlock()
+
+	delete(revisions.revisions, id)
+	return nil
+}
+
+// consume returns the revision for the given resource if it still applies at
+// the given time, counting the read against it. Revisions that no longer apply
+// are removed.
+func consume(all map[string]Revision, id string, now time.Time) *Revision {
+	revision, ok := all[id]
+	if !ok {
+		return nil
+	}
+
+	if revision.Until != nil && !now.Before(*revision.Until) {
+		delete(all, id)
+		return nil
+	}
+
+	if revision.Reads > 0 {
+		revision.Reads--
+		if revision.Reads == 0 {
+			// This is the last read that sees the previous version.
+			delete(all, id)
+			return &revision
+		}
+	}
+
+	all[id] = revision
+	return &revision
+}
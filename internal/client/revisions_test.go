@@ -0,0 +1,80 @@
+// Copyright IBM Corp. 2022, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-provider-tfcoremock/internal/data"
+)
+
+func TestRevisions(t *testing.T) {
+	testCases := []struct {
+		TestCase  string
+		Revisions Revisions
+	}{
+		{
+			TestCase:  "file",
+			Revisions: &FileRevisions{File: filepath.Join(t.TempDir(), "terraform.resource.revisions.json")},
+		},
+		{
+			TestCase:  "memory",
+			Revisions: &MemoryRevisions{},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.TestCase, func(t *testing.T) {
+			revisions := testCase.Revisions
+
+			previous := &data.Resource{ResourceType: "tfcoremock_simple_resource"}
+			if err := revisions.Record("one", Revision{Previous: previous, Reads: 2}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			for read, expected := range []bool{true, true, false} {
+				revision, err := revisions.Consume("one")
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if (revision != nil) != expected {
+					t.Fatalf("expected revision on read %d to be %t", read+1, expected)
+				}
+				if revision != nil && (revision.Previous == nil || revision.Previous.ResourceType != previous.ResourceType) {
+					t.Fatalf("expected the previous revision on read %d", read+1)
+				}
+			}
+
+			past := time.Now().Add(-time.Second)
+			if err := revisions.Record("two", Revision{Until: &past}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if revision, _ := revisions.Consume("two"); revision != nil {
+				t.Fatalf("expected expired revision to be ignored")
+			}
+
+			future := time.Now().Add(time.Hour)
+			if err := revisions.Record("three", Revision{Until: &future}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if revision, _ := revisions.Consume("three"); revision == nil || revision.Previous != nil {
+				t.Fatalf("expected missing revision before it expires")
+			}
+			if err := revisions.Forget("three"); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if revision, _ := revisions.Consume("three"); revision != nil {
+				t.Fatalf("expected forgotten revision to be ignored")
+			}
+
+			if file, ok := revisions.(*FileRevisions); ok {
+				if _, err := os.Stat(file.File); !os.IsNotExist(err) {
+					t.Fatalf("expected the revisions file to be removed, but found %v", err)
+				}
+			}
+		})
+	}
+}
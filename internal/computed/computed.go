@@ -6,6 +6,8 @@ package computed
 import (
 	"errors"
 	"fmt"
+
+	"github.com/hashicorp/terraform-provider-tfcoremock/internal/behaviour"
 	"github.com/hashicorp/terraform-provider-tfcoremock/internal/data"
 	"github.com/hashicorp/terraform-provider-tfcoremock/internal/schema"
 )
@@ -19,26 +21,43 @@ import (
 // Objects are complicated as you can have nested objects with required values
 // so the default value for a computed object is to generate an object with all
 // the required and computed values populated using a default.
-func GenerateComputedValues(resource *data.Resource, schema schema.Schema) error {
-	if err := generateComputedValuesForObject(&resource.Values, schema.AllAttributes()); err != nil {
-		return err
+//
+// Attributes with a generator are given a new value instead. The counter keeps
+// track of sequences and seeded random values between runs of the provider.
+//
+// Attributes with a template are populated in a second pass, once every other
+// value has been generated, so templates can refer to generated values.
+func GenerateComputedValues(resource *data.Resource, schema schema.Schema, counter behaviour.Counter) error {
+	generation := generation{
+		resourceType: resource.ResourceType,
+		id:           resource.GetId(),
+		counter:      counter,
+		root:         resource.Values,
 	}
 
-	if err := generateComputedValuesForBlocks(&resource.Values, schema.Blocks); err != nil {
-		return err
+	for _, templates := range []bool{false, true} {
+		generation.templates = templates
+
+		if err := generateComputedValuesForObject(generation, &resource.Values, schema.AllAttributes()); err != nil {
+			return err
+		}
+
+		if err := generateComputedValuesForBlocks(generation, &resource.Values, schema.Blocks); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
-func generateComputedValuesForBlocks(values *map[string]data.Value, blocks map[string]schema.Block) error {
+func generateComputedValuesForBlocks(generation generation, values *map[string]data.Value, blocks map[string]schema.Block) error {
 	for key, block := range blocks {
 		var err error
 		switch block.Mode {
 		case schema.NestingModeSet:
-			err = generateComputedValuesForBlock((*values)[key].Set, block)
+			err = generateComputedValuesForBlock(generation.at(key), (*values)[key].Set, block)
 		case "", schema.NestingModeList:
-			err = generateComputedValuesForBlock((*values)[key].List, block)
+			err = generateComputedValuesForBlock(generation.at(key), (*values)[key].List, block)
 		default:
 			return errors.New("unrecognized block type: " + block.Mode)
 		}
@@ -50,17 +69,17 @@ func generateComputedValuesForBlocks(values *map[string]data.Value, blocks map[s
 	return nil
 }
 
-func generateComputedValuesForBlock(values *[]data.Value, block schema.Block) error {
+func generateComputedValuesForBlock(generation generation, values *[]data.Value, block schema.Block) error {
 	if values == nil {
 		return nil
 	}
 
 	for ix, value := range *values {
-		if err := generateComputedValuesForObject(value.Object, block.Attributes); err != nil {
+		if err := generateComputedValuesForObject(generation, value.Object, block.Attributes); err != nil {
 			return err
 		}
 
-		if err := generateComputedValuesForBlocks(value.Object, block.Blocks); err != nil {
+		if err := generateComputedValuesForBlocks(generation, value.Object, block.Blocks); err != nil {
 			return err
 		}
 
@@ -70,20 +89,20 @@ func generateComputedValuesForBlock(values *[]data.Value, block schema.Block) er
 	return nil
 }
 
-func generateComputedValue(value data.Value, attribute *schema.Attribute) (data.Value, error) {
+func generateComputedValue(generation generation, value data.Value, attribute *schema.Attribute) (data.Value, error) {
 	var err error
 	switch attribute.Type {
 	case schema.Boolean, schema.Float, schema.Integer, schema.Number, schema.String:
 		// For these types we don't need to do anything, they have a value
 		// set and we're all good to leave them as is.
 	case schema.List:
-		err = generateComputedValuesForList(value.List, attribute.List)
+		err = generateComputedValuesForList(generation, value.List, attribute.List)
 	case schema.Set:
-		err = generateComputedValuesForSet(value.Set, attribute.Set)
+		err = generateComputedValuesForSet(generation, value.Set, attribute.Set)
 	case schema.Map:
-		err = generateComputedValuesForMap(value.Map, attribute.Map)
+		err = generateComputedValuesForMap(generation, value.Map, attribute.Map)
 	case schema.Object:
-		err = generateComputedValuesForObject(value.Object, attribute.Object)
+		err = generateComputedValuesForObject(generation, value.Object, attribute.Object)
 	default:
 		return value, errors.New("unrecognized attribute type: " + string(attribute.Type))
 	}
@@ -91,11 +110,11 @@ func generateComputedValue(value data.Value, attribute *schema.Attribute) (data.
 	return value, err
 }
 
-func generateComputedValuesForList(values *[]data.Value, attribute *schema.Attribute) error {
+func generateComputedValuesForList(generation generation, values *[]data.Value, attribute *schema.Attribute) error {
 	for ix, value := range *values {
 		// Then we're going to go through each value and check if it has any
 		// attributes that need to be computed.
-		newValue, err := generateComputedValue(value, attribute)
+		newValue, err := generateComputedValue(generation, value, attribute)
 		if err != nil {
 			return err
 		}
@@ -104,11 +123,11 @@ func generateComputedValuesForList(values *[]data.Value, attribute *schema.Attri
 	return nil
 }
 
-func generateComputedValuesForSet(values *[]data.Value, attribute *schema.Attribute) error {
+func generateComputedValuesForSet(generation generation, values *[]data.Value, attribute *schema.Attribute) error {
 	for ix, value := range *values {
 		// Then we're going to go through each value and check if it has any
 		// attributes that need to be computed.
-		newValue, err := generateComputedValue(value, attribute)
+		newValue, err := generateComputedValue(generation, value, attribute)
 		if err != nil {
 			return err
 		}
@@ -117,11 +136,11 @@ func generateComputedValuesForSet(values *[]data.Value, attribute *schema.Attrib
 	return nil
 }
 
-func generateComputedValuesForMap(values *map[string]data.Value, attribute *schema.Attribute) error {
+func generateComputedValuesForMap(generation generation, values *map[string]data.Value, attribute *schema.Attribute) error {
 	for key, value := range *values {
 		// Then we're going to go through each value and check if it has any
 		// attributes that need to be computed.
-		newValue, err := generateComputedValue(value, attribute)
+		newValue, err := generateComputedValue(generation, value, attribute)
 		if err != nil {
 			return err
 		}
@@ -130,7 +149,7 @@ func generateComputedValuesForMap(values *map[string]data.Value, attribute *sche
 	return nil
 }
 
-func generateComputedValuesForObject(values *map[string]data.Value, attributes map[string]schema.Attribute) error {
+func generateComputedValuesForObject(generation generation, values *map[string]data.Value, attributes map[string]schema.Attribute) error {
 	for key, attribute := range attributes {
 		if value, ok := (*values)[key]; ok {
 			// This means we already have a value for this attribute, so we're
@@ -138,7 +157,7 @@ func generateComputedValuesForObject(values *map[string]data.Value, attributes m
 			// recurse down into any objects as they maybe have generated
 			// attributes.
 			var err error
-			if (*values)[key], err = generateComputedValue(value, &attribute); err != nil {
+			if (*values)[key], err = generateComputedValue(generation.at(key), value, &attribute); err != nil {
 				return err
 			}
 			continue
@@ -151,9 +170,25 @@ func generateComputedValuesForObject(values *map[string]data.Value, attributes m
 				return fmt.Errorf("attribute %s has specified a value in the json schema without being marked as computed", key)
 			}
 			var err error
-			if (*values)[key], err = generateComputedValue(*attribute.Value, &attribute); err != nil {
+			if (*values)[key], err = generateComputedValue(generation.at(key), *attribute.Value, &attribute); err != nil {
 				return err
 			}
+			continue
+		}
+
+		if attribute.Generator != nil {
+			var err error
+			if (*values)[key], err = generation.at(key).generate(*attribute.Generator, attribute.Type); err != nil {
+				return fmt.Errorf("failed to generate value for %s: %w", key, err)
+			}
+			continue
+		}
+
+		if len(attribute.Template) > 0 && generation.templates {
+			var err error
+			if (*values)[key], err = generation.render(attribute.Template); err != nil {
+				return fmt.Errorf("failed to render template for %s: %w", key, err)
+			}
 		}
 	}
 
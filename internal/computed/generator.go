@@ -0,0 +1,182 @@
+// Copyright IBM Corp. 2022, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package computed
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/big"
+	"math/rand/v2"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/go-uuid"
+
+	"github.com/hashicorp/terraform-provider-tfcoremock/internal/behaviour"
+	"github.com/hashicorp/terraform-provider-tfcoremock/internal/data"
+	"github.com/hashicorp/terraform-provider-tfcoremock/internal/schema"
+)
+
+const randomStringCharacters = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// generation holds what the generators need to know about the resource and
+// attribute they are generating values for.
+type generation struct {
+	resourceType string
+	id           string
+	counter      behaviour.Counter
+
+	// path names the attribute being generated, ignoring any list, map, or
+	// set elements along the way.
+	path string
+
+	// root holds the top level values of the resource, which templates are
+	// rendered against.
+	root map[string]data.Value
+
+	// templates is true once every other value has been generated, and the
+	// templates can be rendered.
+	templates bool
+}
+
+// at returns the generation for the named attribute within the current one.
+func (g generation) at(name string) generation {
+	if len(g.path) > 0 {
+		name = g.path + "." + name
+	}
+	g.path = name
+	return g
+}
+
+// generate makes a new value for an attribute of the given type.
+func (g generation) generate(generator schema.Generator, attributeType schema.Type) (data.Value, error) {
+	switch generator.Type {
+	case schema.UUIDGenerator:
+		if generator.Seed == nil {
+			id, err := uuid.GenerateUUID()
+			if err != nil {
+				return data.Value{}, err
+			}
+			return data.Value{String: &id}, nil
+		}
+
+		random, err := g.random(generator.Seed)
+		if err != nil {
+			return data.Value{}, err
+		}
+
+		bytes := make([]byte, 16)
+		for ix := range bytes {
+			bytes[ix] = byte(random.UintN(256))
+		}
+		id, err := uuid.FormatUUID(bytes)
+		if err != nil {
+			return data.Value{}, err
+		}
+		return data.Value{String: &id}, nil
+	case schema.TimestampGenerator:
+		timestamp := time.Now().UTC().Format(time.RFC3339)
+		return data.Value{String: &timestamp}, nil
+	case schema.SequenceGenerator:
+		// Sequences are shared by every resource of the same type, so each new
+		// value is greater than all those before it.
+		next, err := g.counter.Increment(fmt.Sprintf("sequence/%s/%s", g.resourceType, g.path))
+		if err != nil {
+			return data.Value{}, fmt.Errorf("failed to count sequence: %w", err)
+		}
+		return integer(next, attributeType), nil
+	case schema.RandomStringGenerator:
+		random, err := g.random(generator.Seed)
+		if err != nil {
+			return data.Value{}, err
+		}
+
+		length := generator.Length
+		if length == 0 {
+			length = schema.DefaultRandomStringLength
+		}
+
+		bytes := make([]byte, length)
+		for ix := range bytes {
+			bytes[ix] = randomStringCharacters[random.IntN(len(randomStringCharacters))]
+		}
+		value := string(bytes)
+		return data.Value{String: &value}, nil
+	case schema.RandomIntGenerator:
+		random, err := g.random(generator.Seed)
+		if err != nil {
+			return data.Value{}, err
+		}
+
+		min, max := generator.Bounds()
+		return integer(min+int64(random.Uint64N(uint64(max-min)+1)), attributeType), nil
+	default:
+		return data.Value{}, fmt.Errorf("unrecognized generator type '%s'", generator.Type)
+	}
+}
+
+// render makes the value for an attribute with the given template.
+func (g generation) render(template schema.Template) (data.Value, error) {
+	value, _, err := template.Render(func(reference []string) (string, bool, error) {
+		current, ok := g.root[reference[0]]
+		for _, name := range reference[1:] {
+			if !ok || current.Object == nil {
+				break
+			}
+			current, ok = (*current.Object)[name]
+		}
+
+		if !ok {
+			// Missing values are null, and null values render as empty
+			// strings.
+			return "", true, nil
+		}
+
+		switch {
+		case current.Boolean != nil:
+			return strconv.FormatBool(*current.Boolean), true, nil
+		case current.Number != nil:
+			return current.Number.Text('f', -1), true, nil
+		case current.String != nil:
+			return *current.String, true, nil
+		default:
+			return "", true, nil
+		}
+	})
+	if err != nil {
+		return data.Value{}, err
+	}
+	return data.Value{String: &value}, nil
+}
+
+// random returns a random source derived from the seed, the attribute being
+// generated, and how many values have been generated for it already. This
+// means a resource will always see the same sequence of values for the same
+// seed. Without a seed, the source is not reproducible.
+func (g generation) random(seed *int64) (*rand.Rand, error) {
+	if seed == nil {
+		return rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64())), nil
+	}
+
+	key := fmt.Sprintf("random/%s/%s/%s", g.resourceType, g.id, g.path)
+
+	call, err := g.counter.Increment(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count random values: %w", err)
+	}
+
+	hash := fnv.New64a()
+	_, _ = hash.Write([]byte(key))
+	return rand.New(rand.NewPCG(uint64(*seed), hash.Sum64()^uint64(call))), nil
+}
+
+// integer converts a generated integer into a value for an attribute of the
+// given type.
+func integer(value int64, attributeType schema.Type) data.Value {
+	if attributeType == schema.String {
+		str := strconv.FormatInt(value, 10)
+		return data.Value{String: &str}
+	}
+	return data.Value{Number: new(big.Float).SetInt64(value)}
+}
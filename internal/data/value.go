@@ -5,7 +5,9 @@ package data
 
 import (
 	"errors"
+	"maps"
 	"math/big"
+	"slices"
 
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
@@ -277,3 +279,36 @@ func setFromTerraform5Value(v tftypes.Value) (Value, error) {
 		Set: &set,
 	}, nil
 }
+
+// Equal returns true if the two values are the same. Numbers are compared by
+// their shortest decimal representation, so the same number parsed with
+// different precisions is still equal, and sets are compared regardless of the
+// order of their elements.
+func (v Value) Equal(other Value) bool {
+	switch {
+	case v.Boolean != nil || other.Boolean != nil:
+		return v.Boolean != nil && other.Boolean != nil && *v.Boolean == *other.Boolean
+	case v.Number != nil || other.Number != nil:
+		return v.Number != nil && other.Number != nil && v.Number.Text('g', -1) == other.Number.Text('g', -1)
+	case v.String != nil || other.String != nil:
+		return v.String != nil && other.String != nil && *v.String == *other.String
+	case v.List != nil || other.List != nil:
+		return v.List != nil && other.List != nil && slices.EqualFunc(*v.List, *other.List, Value.Equal)
+	case v.Map != nil || other.Map != nil:
+		return v.Map != nil && other.Map != nil && maps.EqualFunc(*v.Map, *other.Map, Value.Equal)
+	case v.Object != nil || other.Object != nil:
+		return v.Object != nil && other.Object != nil && maps.EqualFunc(*v.Object, *other.Object, Value.Equal)
+	case v.Set != nil || other.Set != nil:
+		if v.Set == nil || other.Set == nil || len(*v.Set) != len(*other.Set) {
+			return false
+		}
+		for _, element := range *v.Set {
+			if !slices.ContainsFunc(*other.Set, element.Equal) {
+				return false
+			}
+		}
+		return true
+	default:
+		return true
+	}
+}
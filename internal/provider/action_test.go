@@ -4,6 +4,7 @@
 package provider
 
 import (
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/go-version"
@@ -40,3 +41,19 @@ func TestAccDynamicAction(t *testing.T) {
 		},
 	})
 }
+
+func TestAccSimpleActionFailsOnInvoke(t *testing.T) {
+	t.Cleanup(CleanupTestingDirectories(t))
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: ProviderFactories(""),
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(version.Must(version.NewVersion("1.14.0-beta1"))),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config:      LoadFile(t, "testdata/fail_on/invoke/main.tf"),
+				ExpectError: regexp.MustCompile("forced failure"),
+			},
+		},
+	})
+}
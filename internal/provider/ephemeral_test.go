@@ -0,0 +1,130 @@
+// Copyright IBM Corp. 2022, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccSimpleEphemeralResource(t *testing.T) {
+	t.Cleanup(CleanupTestingDirectories(t))
+	t.Cleanup(CleanupEphemeralLog(t))
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: ProviderFactories(""),
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0), // ephemeral resources
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: LoadFile(t, "testdata/ephemeral/simple.tf"),
+				Check:  CheckEphemeralLog("my-ephemeral-resource"),
+			},
+		},
+	})
+}
+
+func TestAccDynamicEphemeralResource(t *testing.T) {
+	t.Cleanup(CleanupTestingDirectories(t))
+	t.Cleanup(CleanupEphemeralLog(t))
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: ProviderFactories(LoadFile(t, "testdata/ephemeral/dynamic_resources.json")),
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0), // write-only attributes
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: LoadFile(t, "testdata/ephemeral/dynamic.tf"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("tfcoremock_dynamic_resource.test", "password"),
+					CheckEphemeralLog("my-ephemeral-resource")),
+			},
+			{
+				Config: LoadFile(t, "testdata/dynamic/delete/main.tf"),
+			},
+		},
+	})
+}
+
+func TestAccDynamicEphemeralResourceWithGenerators(t *testing.T) {
+	t.Cleanup(CleanupTestingDirectories(t))
+	t.Cleanup(CleanupEphemeralLog(t))
+	t.Cleanup(CleanupInvocationCounts(t))
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: ProviderFactories(LoadFile(t, "testdata/ephemeral_generators/dynamic_resources.json")),
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0), // ephemeral resources
+		},
+		Steps: []resource.TestStep{
+			{
+				// The ephemeral resource is opened during both the plan and
+				// the apply, but its sequence is separate from the one for the
+				// managed resource.
+				Config: LoadFile(t, "testdata/ephemeral_generators/main.tf"),
+				Check:  resource.TestCheckResourceAttr("tfcoremock_dynamic_resource.test", "version", "1"),
+			},
+			{
+				Config: LoadFile(t, "testdata/dynamic/delete/main.tf"),
+			},
+		},
+	})
+}
+
+// CheckEphemeralLog checks that every time the ephemeral resource with the
+// given id was opened, it was also closed.
+func CheckEphemeralLog(id string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		file, err := os.Open("terraform.resource.ephemeral.log")
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		var operations []string
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			var event struct {
+				Operation string `json:"operation"`
+				ID        string `json:"id"`
+			}
+			if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+				return err
+			}
+			if event.ID == id {
+				operations = append(operations, event.Operation)
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			return err
+		}
+
+		if len(operations) == 0 {
+			return fmt.Errorf("expected %s to have been opened", id)
+		}
+		var open int
+		for _, operation := range operations {
+			switch operation {
+			case "open":
+				open++
+			case "close":
+				open--
+			}
+			if open < 0 {
+				return fmt.Errorf("expected %s to be opened before it was closed, but found %v", id, operations)
+			}
+		}
+		if open != 0 || operations[len(operations)-1] != "close" || !slices.Contains(operations, "open") {
+			return fmt.Errorf("expected %s to have been closed every time it was opened, but found %v", id, operations)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright IBM Corp. 2022, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccDynamicFunctions(t *testing.T) {
+	t.Cleanup(CleanupTestingDirectories(t))
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: ProviderFactories(LoadFile(t, "testdata/functions/dynamic_resources.json")),
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0), // provider functions
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: LoadFile(t, "testdata/functions/main.tf"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("tfcoremock_simple_resource.test", "string", "US East (N. Virginia)"),
+					resource.TestCheckOutput("default", "Unknown"),
+					resource.TestCheckOutput("total", "3"),
+					resource.TestCheckOutput("identity", "one,two")),
+			},
+			{
+				Config: LoadFile(t, "testdata/dynamic/delete/main.tf"),
+			},
+		},
+	})
+}
+
+func TestAccStaticFunctions(t *testing.T) {
+	t.Cleanup(CleanupTestingDirectories(t))
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: ProviderFactories(""),
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0), // provider functions
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: LoadFile(t, "testdata/static_functions/main.tf"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("tfcoremock_simple_resource.test", "string", "hello"),
+					resource.TestCheckOutput("echo", "hello"),
+					resource.TestCheckOutput("sensitive_echo", "secret"),
+					resource.TestCheckOutput("unknown_passthrough", "my-function-resource"),
+					resource.TestCheckOutput("variadic_count", "3")),
+			},
+			{
+				Config: LoadFile(t, "testdata/dynamic/delete/main.tf"),
+			},
+		},
+	})
+}
+
+func TestAccStaticFunctionFailure(t *testing.T) {
+	t.Cleanup(CleanupTestingDirectories(t))
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: ProviderFactories(""),
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0), // provider functions
+		},
+		Steps: []resource.TestStep{
+			{
+				Config:      LoadFile(t, "testdata/static_functions/fail.tf"),
+				ExpectError: regexp.MustCompile("forced failure"),
+			},
+		},
+	})
+}
@@ -4,6 +4,7 @@
 package provider
 
 import (
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/go-version"
@@ -77,3 +78,23 @@ func TestAccDynamicResourceList(t *testing.T) {
 		},
 	})
 }
+
+func TestAccSimpleResourceListFailsAfterResults(t *testing.T) {
+	t.Cleanup(CleanupTestingDirectories(t))
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: ProviderFactories(""),
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(version.Must(version.NewVersion("1.14.0"))),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: LoadFile(t, "testdata/fail_on/list/main.tf"),
+			},
+			{
+				Query:       true,
+				Config:      LoadFile(t, "testdata/fail_on/list/main.tfquery.hcl"),
+				ExpectError: regexp.MustCompile("lost connection"),
+			},
+		},
+	})
+}
@@ -5,13 +5,17 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/action"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
@@ -19,7 +23,9 @@ import (
 	tfresource "github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
+	"github.com/hashicorp/terraform-provider-tfcoremock/internal/behaviour"
 	"github.com/hashicorp/terraform-provider-tfcoremock/internal/client"
+	"github.com/hashicorp/terraform-provider-tfcoremock/internal/data"
 	"github.com/hashicorp/terraform-provider-tfcoremock/internal/resource"
 	"github.com/hashicorp/terraform-provider-tfcoremock/internal/schema/complex"
 	"github.com/hashicorp/terraform-provider-tfcoremock/internal/schema/dynamic"
@@ -29,6 +35,8 @@ import (
 var _ provider.Provider = &tfcoremockProvider{}
 var _ provider.ProviderWithActions = &tfcoremockProvider{}
 var _ provider.ProviderWithListResources = &tfcoremockProvider{}
+var _ provider.ProviderWithEphemeralResources = &tfcoremockProvider{}
+var _ provider.ProviderWithFunctions = &tfcoremockProvider{}
 
 const (
 	description = `The 'tfcoremock' provider is intended to aid with testing the Terraform core libraries and the Terraform CLI. This provider should allow users to define all possible Terraform configurations and run them through the Terraform core platform.
@@ -48,7 +56,11 @@ All resources (and data sources) supplied by the provider have an 'id' attribute
 
 Additionally, all resources are available to be queried via 'list' blocks. For now only the 'id' attribute is supported as a field to retrieve a specific instance. It is optional, so all resources of the specified type will be returned if the field is left blank.
 
-The provider also supports actions (introduced in Terraform v1.14). All resources (both static and dynamic) are made available as action blocks, that can be plugged into any Terraform configuration. Unlike resources and data sources, actions have no 'id' associated with them as they are not written to disk.`
+The provider also supports actions (introduced in Terraform v1.14). All resources (both static and dynamic) are made available as action blocks, that can be plugged into any Terraform configuration. Unlike resources and data sources, actions have no 'id' associated with them as they are not written to disk.
+
+The provider also supports ephemeral resources (introduced in Terraform v1.10). All resources (both static and dynamic) are made available as ephemeral resources, which return their configuration along with any computed values when they are opened. Ephemeral resources have an optional 'renew_at' attribute, such as '30s', that makes Terraform renew them after that long. Every open, renew, and close is appended as a line of JSON to a log file next to the resource directory, which defaults to 'terraform.resource.ephemeral.log'. Nothing is logged if 'use_only_state' is set.
+
+The provider also supplies static functions (introduced in Terraform v1.8) for testing function calls. The 'echo' and 'sensitive_echo' functions return their argument unchanged, 'unknown_passthrough' does the same even when its argument is unknown, 'variadic_count' counts its arguments, and 'fail' returns an error attributed to a chosen argument. Additional functions can be defined in the 'functions' object of the 'dynamic_resources.json' file.`
 
 	markdownDescription = `The ''tfcoremock'' provider is intended to aid with testing the Terraform core libraries and the Terraform CLI. This provider should allow users to define all possible Terraform configurations and run them through the Terraform core platform.
 
@@ -67,9 +79,14 @@ All resources (and data sources) supplied by the provider have an ''id'' attribu
 
 Additionally, all resources are available to be queried via ''list'' blocks. For now only the ''id'' attribute is supported as a field to retrieve a specific instance. It is optional, so all resources of the specified type will be returned if the field is left blank.
 
-The provider also supports actions (introduced in Terraform v1.14). All resources (both static and dynamic) are made available as action blocks, that can be plugged into any Terraform configuration. Unlike resources and data sources, actions have no ''id'' associated with them as they are not written to disk.`
+The provider also supports actions (introduced in Terraform v1.14). All resources (both static and dynamic) are made available as action blocks, that can be plugged into any Terraform configuration. Unlike resources and data sources, actions have no ''id'' associated with them as they are not written to disk.
+
+The provider also supports ephemeral resources (introduced in Terraform v1.10). All resources (both static and dynamic) are made available as ephemeral resources, which return their configuration along with any computed values when they are opened. Ephemeral resources have an optional ''renew_at'' attribute, such as ''30s'', that makes Terraform renew them after that long. Every open, renew, and close is appended as a line of JSON to a log file next to the resource directory, which defaults to ''terraform.resource.ephemeral.log''. Nothing is logged if ''use_only_state'' is set.
+
+The provider also supplies static functions (introduced in Terraform v1.8) for testing function calls. The ''echo'' and ''sensitive_echo'' functions return their argument unchanged, ''unknown_passthrough'' does the same even when its argument is unknown, ''variadic_count'' counts its arguments, and ''fail'' returns an error attributed to a chosen argument. Additional functions can be defined in the ''functions'' object of the ''dynamic_resources.json'' file.`
 
 	dynamicResourcesPathEnvVarName = "TFCOREMOCK_DYNAMIC_RESOURCES_FILE"
+	faultsPathEnvVarName           = "TFCOREMOCK_FAULTS_FILE"
 )
 
 type tfcoremockProvider struct {
@@ -86,11 +103,31 @@ type tfcoremockProvider struct {
 	// recorded and written to a backend other than the terraform state.
 	client client.Client
 
-	failOnCreate []string
-	failOnUpdate []string
-	failOnRead   []string
-	failOnDelete []string
-	deferChanges []string
+	// behaviours holds the failures, deferrals, delays, crashes, drifts and
+	// inconsistencies that the resources should apply to themselves, built
+	// from the provider configuration.
+	behaviours behaviour.Behaviours
+
+	// faults reads the additional behaviours in the faults file, if one has
+	// been set. The file is read for every operation, rather than once here.
+	faults behaviour.Reader
+
+	// counter records how many times each operation has been invoked for any
+	// failures that have a schedule.
+	counter behaviour.Counter
+
+	// revisions records the previous versions of resources for any
+	// inconsistencies, so reads can return them after the resources change.
+	revisions client.Revisions
+
+	// ephemeralLog records every open, renew and close of the ephemeral
+	// resources.
+	ephemeralLog *resource.EphemeralLog
+
+	// deferred is true if the provider deferred its own configuration because
+	// it contained unknown values. The framework defers resources and data
+	// sources automatically, but list resources have to handle it themselves.
+	deferred bool
 }
 
 type providerData struct {
@@ -98,15 +135,125 @@ type providerData struct {
 	DataDirectory     types.String `tfsdk:"data_directory"`
 	UseOnlyState      types.Bool   `tfsdk:"use_only_state"`
 
+	DeferOnUnknownConfig types.Bool `tfsdk:"defer_on_unknown_config"`
+
 	FailOnCreate types.List `tfsdk:"fail_on_create"`
 	FailOnUpdate types.List `tfsdk:"fail_on_update"`
 	FailOnRead   types.List `tfsdk:"fail_on_read"`
 	FailOnDelete types.List `tfsdk:"fail_on_delete"`
 
+	FailOnImport         types.List `tfsdk:"fail_on_import"`
+	FailOnReadDataSource types.List `tfsdk:"fail_on_read_data_source"`
+	FailOnList           types.List `tfsdk:"fail_on_list"`
+	FailOnInvoke         types.List `tfsdk:"fail_on_invoke"`
+
 	DeferChanges types.List `tfsdk:"defer_changes"`
+
+	Failures  []failureData  `tfsdk:"failure"`
+	Deferrals []deferralData `tfsdk:"deferral"`
+	Delays    []delayData    `tfsdk:"delay"`
+	Crashes   []crashData    `tfsdk:"crash"`
+	Drifts    []driftData    `tfsdk:"drift"`
+
+	Inconsistencies []inconsistencyData `tfsdk:"inconsistency"`
+}
+
+type targetData struct {
+	ResourceType types.String `tfsdk:"resource_type"`
+	ID           types.String `tfsdk:"id"`
+	Match        types.String `tfsdk:"match"`
+}
+
+type failureData struct {
+	Operations   types.List  `tfsdk:"operations"`
+	Partial      types.Bool  `tfsdk:"partial"`
+	AfterResults types.Int64 `tfsdk:"after_results"`
+	targetData
+	scheduleData
+	diagnosticData
+}
+
+type diagnosticData struct {
+	Severity  types.String `tfsdk:"severity"`
+	Summary   types.String `tfsdk:"summary"`
+	Detail    types.String `tfsdk:"detail"`
+	Attribute types.String `tfsdk:"attribute"`
+}
+
+type scheduleData struct {
+	OnCall      types.Int64   `tfsdk:"on_call"`
+	FirstCalls  types.Int64   `tfsdk:"first_calls"`
+	Probability types.Float64 `tfsdk:"probability"`
+	Seed        types.Int64   `tfsdk:"seed"`
+}
+
+type deferralData struct {
+	Operations        types.List   `tfsdk:"operations"`
+	Reason            types.String `tfsdk:"reason"`
+	WhenUnknown       types.Bool   `tfsdk:"when_unknown"`
+	UnknownAttributes types.List   `tfsdk:"unknown_attributes"`
+	targetData
+}
+
+type crashData struct {
+	Operations types.List   `tfsdk:"operations"`
+	Mode       types.String `tfsdk:"mode"`
+	ExitCode   types.Int64  `tfsdk:"exit_code"`
+	targetData
+	scheduleData
+}
+
+type driftData struct {
+	Remove types.Bool `tfsdk:"remove"`
+	Values types.Map  `tfsdk:"values"`
+	targetData
+	scheduleData
+}
+
+type inconsistencyData struct {
+	Operations     types.List   `tfsdk:"operations"`
+	Reads          types.Int64  `tfsdk:"reads"`
+	Duration       types.String `tfsdk:"duration"`
+	Mode           types.String `tfsdk:"mode"`
+	ReadAfterWrite types.Bool   `tfsdk:"read_after_write"`
+	targetData
+}
+
+type delayData struct {
+	Operations types.List   `tfsdk:"operations"`
+	Duration   types.String `tfsdk:"duration"`
+	targetData
+	scheduleData
 }
 
 func (m *tfcoremockProvider) Configure(ctx context.Context, request provider.ConfigureRequest, response *provider.ConfigureResponse) {
+	// We check this before reading the rest of the configuration, as blocks
+	// that are entirely unknown can't be read into the provider data.
+	var deferOnUnknownConfig types.Bool
+	response.Diagnostics.Append(request.Config.GetAttribute(ctx, path.Root("defer_on_unknown_config"), &deferOnUnknownConfig)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	if deferOnUnknownConfig.IsUnknown() {
+		response.Diagnostics.AddAttributeError(path.Root("defer_on_unknown_config"), "value is unknown", "unknown values are not permitted")
+		return
+	}
+
+	m.deferred = false
+	if deferOnUnknownConfig.ValueBool() && !request.Config.Raw.IsFullyKnown() {
+		if !request.ClientCapabilities.DeferralAllowed {
+			response.Diagnostics.AddError("Invalid provider deferral", "The provider configuration contains unknown values and was marked as \"should be deferred\", but the current version of Terraform does not support deferrals.")
+			return
+		}
+
+		response.Deferred = &provider.Deferred{
+			Reason: provider.DeferredReasonProviderConfigUnknown,
+		}
+		m.deferred = true
+		return
+	}
+
 	var data providerData
 	response.Diagnostics.Append(request.Config.Get(ctx, &data)...)
 	if response.Diagnostics.HasError() {
@@ -122,6 +269,12 @@ func (m *tfcoremockProvider) Configure(ctx context.Context, request provider.Con
 		m.client = client.State{
 			DataDirectory: directory,
 		}
+
+		// We can't write anything to disk, so invocations are only counted
+		// for the lifetime of this provider.
+		m.counter = &behaviour.MemoryCounter{}
+		m.revisions = &client.MemoryRevisions{}
+		m.ephemeralLog = &resource.EphemeralLog{}
 	} else {
 		dataDirectory := "terraform.data"
 		resourceDirectory := "terraform.resource"
@@ -138,28 +291,382 @@ func (m *tfcoremockProvider) Configure(ctx context.Context, request provider.Con
 			ResourceDirectory: resourceDirectory,
 			DataDirectory:     dataDirectory,
 		}
+
+		// The counts are kept next to, rather than inside, the resource
+		// directory so the directory is still tidied up once the last resource
+		// is deleted.
+		m.counter = &behaviour.FileCounter{
+			File: filepath.Clean(resourceDirectory) + ".invocations.json",
+		}
+		m.revisions = &client.FileRevisions{
+			File: filepath.Clean(resourceDirectory) + ".revisions.json",
+		}
+		m.ephemeralLog = &resource.EphemeralLog{
+			File: filepath.Clean(resourceDirectory) + ".ephemeral.log",
+		}
 	}
 
-	failOnDelete, failOnDeleteDiags := parseStringList(ctx, data.FailOnDelete, "fail_on_delete")
-	failOnCreate, failOnCreateDiags := parseStringList(ctx, data.FailOnCreate, "fail_on_create")
-	failOnRead, failOnReadDiags := parseStringList(ctx, data.FailOnRead, "fail_on_read")
-	failOnUpdate, failOnUpdateDiags := parseStringList(ctx, data.FailOnUpdate, "fail_on_update")
-	deferChanges, deferChangesDiags := parseStringList(ctx, data.DeferChanges, "defer_changes")
+	// Reads only differ from the underlying client while an inconsistency has
+	// recorded a previous revision of the resource being read.
+	m.client = client.Eventual{
+		Client:    m.client,
+		Revisions: m.revisions,
+	}
+
+	failOnDelete, failOnDeleteDiags := parseStringList(ctx, data.FailOnDelete, path.Root("fail_on_delete"))
+	failOnCreate, failOnCreateDiags := parseStringList(ctx, data.FailOnCreate, path.Root("fail_on_create"))
+	failOnRead, failOnReadDiags := parseStringList(ctx, data.FailOnRead, path.Root("fail_on_read"))
+	failOnUpdate, failOnUpdateDiags := parseStringList(ctx, data.FailOnUpdate, path.Root("fail_on_update"))
+	failOnImport, failOnImportDiags := parseStringList(ctx, data.FailOnImport, path.Root("fail_on_import"))
+	failOnReadDataSource, failOnReadDataSourceDiags := parseStringList(ctx, data.FailOnReadDataSource, path.Root("fail_on_read_data_source"))
+	failOnList, failOnListDiags := parseStringList(ctx, data.FailOnList, path.Root("fail_on_list"))
+	failOnInvoke, failOnInvokeDiags := parseStringList(ctx, data.FailOnInvoke, path.Root("fail_on_invoke"))
+	deferChanges, deferChangesDiags := parseStringList(ctx, data.DeferChanges, path.Root("defer_changes"))
 
 	response.Diagnostics.Append(failOnDeleteDiags...)
 	response.Diagnostics.Append(failOnCreateDiags...)
 	response.Diagnostics.Append(failOnReadDiags...)
 	response.Diagnostics.Append(failOnUpdateDiags...)
+	response.Diagnostics.Append(failOnImportDiags...)
+	response.Diagnostics.Append(failOnReadDataSourceDiags...)
+	response.Diagnostics.Append(failOnListDiags...)
+	response.Diagnostics.Append(failOnInvokeDiags...)
 	response.Diagnostics.Append(deferChangesDiags...)
 
-	m.failOnDelete = failOnDelete
-	m.failOnCreate = failOnCreate
-	m.failOnRead = failOnRead
-	m.failOnUpdate = failOnUpdate
-	m.deferChanges = deferChanges
+	var behaviours behaviour.Behaviours
+
+	// The original fail_on_* and defer_changes attributes are just shorthand
+	// for exact matches against the resource ID, so we convert them into the
+	// same representation as the more flexible blocks.
+	for _, failOn := range []struct {
+		operation behaviour.Operation
+		ids       []string
+	}{
+		{behaviour.Delete, failOnDelete},
+		{behaviour.Create, failOnCreate},
+		{behaviour.Read, failOnRead},
+		{behaviour.Update, failOnUpdate},
+		{behaviour.Import, failOnImport},
+		{behaviour.ReadDataSource, failOnReadDataSource},
+	} {
+		for _, id := range failOn.ids {
+			behaviours.Failures = append(behaviours.Failures, behaviour.Failure{
+				Target: behaviour.Target{
+					Operations: []behaviour.Operation{failOn.operation},
+					ID:         id,
+					Match:      behaviour.MatchExact,
+				},
+			})
+		}
+	}
+
+	// List resources and actions don't have a single ID, so their shorthand
+	// attributes match against the type instead.
+	for _, failOn := range []struct {
+		operation behaviour.Operation
+		types     []string
+	}{
+		{behaviour.List, failOnList},
+		{behaviour.Invoke, failOnInvoke},
+	} {
+		for _, typ := range failOn.types {
+			behaviours.Failures = append(behaviours.Failures, behaviour.Failure{
+				Target: behaviour.Target{
+					Operations:   []behaviour.Operation{failOn.operation},
+					ResourceType: typ,
+					Match:        behaviour.MatchExact,
+				},
+			})
+		}
+	}
+	for _, id := range deferChanges {
+		behaviours.Deferrals = append(behaviours.Deferrals, behaviour.Deferral{
+			Target: behaviour.Target{
+				ID:    id,
+				Match: behaviour.MatchExact,
+			},
+		})
+	}
+
+	for ix, failure := range data.Failures {
+		attr := path.Root("failure").AtListIndex(ix)
+
+		operations, diags := parseStringList(ctx, failure.Operations, attr.AtName("operations"))
+		response.Diagnostics.Append(diags...)
+
+		target, diags := parseTarget(failure.targetData, attr)
+		response.Diagnostics.Append(diags...)
+		for _, operation := range operations {
+			target.Operations = append(target.Operations, behaviour.Operation(operation))
+		}
+
+		schedule, diags := parseSchedule(failure.scheduleData, attr)
+		response.Diagnostics.Append(diags...)
+
+		diagnostic, diags := parseDiagnostic(failure.diagnosticData, attr)
+		response.Diagnostics.Append(diags...)
+
+		if failure.Partial.IsUnknown() {
+			response.Diagnostics.AddAttributeError(attr.AtName("partial"), "value is unknown", "unknown values are not permitted")
+		}
+		if failure.AfterResults.IsUnknown() {
+			response.Diagnostics.AddAttributeError(attr.AtName("after_results"), "value is unknown", "unknown values are not permitted")
+		}
+
+		failure := behaviour.Failure{
+			Target:       target,
+			Schedule:     schedule,
+			Diagnostic:   diagnostic,
+			Partial:      failure.Partial.ValueBool(),
+			AfterResults: failure.AfterResults.ValueInt64(),
+		}
+		if err := failure.Validate(); err != nil {
+			response.Diagnostics.AddAttributeError(attr, "invalid failure", err.Error())
+			continue
+		}
+		behaviours.Failures = append(behaviours.Failures, failure)
+	}
+
+	for ix, deferral := range data.Deferrals {
+		attr := path.Root("deferral").AtListIndex(ix)
+
+		operations, diags := parseStringList(ctx, deferral.Operations, attr.AtName("operations"))
+		response.Diagnostics.Append(diags...)
+
+		target, diags := parseTarget(deferral.targetData, attr)
+		response.Diagnostics.Append(diags...)
+		for _, operation := range operations {
+			target.Operations = append(target.Operations, behaviour.Operation(operation))
+		}
+
+		unknownAttributes, diags := parseStringList(ctx, deferral.UnknownAttributes, attr.AtName("unknown_attributes"))
+		response.Diagnostics.Append(diags...)
+
+		if deferral.Reason.IsUnknown() {
+			response.Diagnostics.AddAttributeError(attr.AtName("reason"), "value is unknown", "unknown values are not permitted")
+		}
+		if deferral.WhenUnknown.IsUnknown() {
+			response.Diagnostics.AddAttributeError(attr.AtName("when_unknown"), "value is unknown", "unknown values are not permitted")
+		}
+
+		deferral := behaviour.Deferral{
+			Target:            target,
+			Reason:            behaviour.DeferralReason(deferral.Reason.ValueString()),
+			WhenUnknown:       deferral.WhenUnknown.ValueBool(),
+			UnknownAttributes: unknownAttributes,
+		}
+		if err := deferral.Validate(); err != nil {
+			response.Diagnostics.AddAttributeError(attr, "invalid deferral", err.Error())
+			continue
+		}
+		behaviours.Deferrals = append(behaviours.Deferrals, deferral)
+	}
+
+	for ix, delay := range data.Delays {
+		attr := path.Root("delay").AtListIndex(ix)
+
+		operations, diags := parseStringList(ctx, delay.Operations, attr.AtName("operations"))
+		response.Diagnostics.Append(diags...)
+
+		target, diags := parseTarget(delay.targetData, attr)
+		response.Diagnostics.Append(diags...)
+		for _, operation := range operations {
+			target.Operations = append(target.Operations, behaviour.Operation(operation))
+		}
+
+		schedule, diags := parseSchedule(delay.scheduleData, attr)
+		response.Diagnostics.Append(diags...)
+
+		if delay.Duration.IsUnknown() {
+			response.Diagnostics.AddAttributeError(attr.AtName("duration"), "value is unknown", "unknown values are not permitted")
+		}
+
+		delay := behaviour.Delay{
+			Target:   target,
+			Schedule: schedule,
+			Duration: delay.Duration.ValueString(),
+		}
+		if err := delay.Validate(); err != nil {
+			response.Diagnostics.AddAttributeError(attr, "invalid delay", err.Error())
+			continue
+		}
+		behaviours.Delays = append(behaviours.Delays, delay)
+	}
+
+	for ix, crash := range data.Crashes {
+		attr := path.Root("crash").AtListIndex(ix)
+
+		operations, diags := parseStringList(ctx, crash.Operations, attr.AtName("operations"))
+		response.Diagnostics.Append(diags...)
+
+		target, diags := parseTarget(crash.targetData, attr)
+		response.Diagnostics.Append(diags...)
+		for _, operation := range operations {
+			target.Operations = append(target.Operations, behaviour.Operation(operation))
+		}
+
+		schedule, diags := parseSchedule(crash.scheduleData, attr)
+		response.Diagnostics.Append(diags...)
+
+		if crash.Mode.IsUnknown() {
+			response.Diagnostics.AddAttributeError(attr.AtName("mode"), "value is unknown", "unknown values are not permitted")
+		}
+		if crash.ExitCode.IsUnknown() {
+			response.Diagnostics.AddAttributeError(attr.AtName("exit_code"), "value is unknown", "unknown values are not permitted")
+		}
+
+		crash := behaviour.Crash{
+			Target:   target,
+			Schedule: schedule,
+			Mode:     behaviour.CrashMode(crash.Mode.ValueString()),
+			ExitCode: int(crash.ExitCode.ValueInt64()),
+		}
+		if err := crash.Validate(); err != nil {
+			response.Diagnostics.AddAttributeError(attr, "invalid crash", err.Error())
+			continue
+		}
+		behaviours.Crashes = append(behaviours.Crashes, crash)
+	}
+
+	for ix, drift := range data.Drifts {
+		attr := path.Root("drift").AtListIndex(ix)
+
+		target, diags := parseTarget(drift.targetData, attr)
+		response.Diagnostics.Append(diags...)
+
+		schedule, diags := parseSchedule(drift.scheduleData, attr)
+		response.Diagnostics.Append(diags...)
+
+		if drift.Remove.IsUnknown() {
+			response.Diagnostics.AddAttributeError(attr.AtName("remove"), "value is unknown", "unknown values are not permitted")
+		}
+
+		values, diags := parseValues(ctx, drift.Values, attr.AtName("values"))
+		response.Diagnostics.Append(diags...)
+
+		drift := behaviour.Drift{
+			Target:   target,
+			Schedule: schedule,
+			Remove:   drift.Remove.ValueBool(),
+			Values:   values,
+		}
+		if err := drift.Validate(); err != nil {
+			response.Diagnostics.AddAttributeError(attr, "invalid drift", err.Error())
+			continue
+		}
+		behaviours.Drifts = append(behaviours.Drifts, drift)
+	}
+
+	for ix, inconsistency := range data.Inconsistencies {
+		attr := path.Root("inconsistency").AtListIndex(ix)
+
+		operations, diags := parseStringList(ctx, inconsistency.Operations, attr.AtName("operations"))
+		response.Diagnostics.Append(diags...)
+
+		target, diags := parseTarget(inconsistency.targetData, attr)
+		response.Diagnostics.Append(diags...)
+		for _, operation := range operations {
+			target.Operations = append(target.Operations, behaviour.Operation(operation))
+		}
+
+		for name, unknown := range map[string]bool{
+			"reads":            inconsistency.Reads.IsUnknown(),
+			"duration":         inconsistency.Duration.IsUnknown(),
+			"mode":             inconsistency.Mode.IsUnknown(),
+			"read_after_write": inconsistency.ReadAfterWrite.IsUnknown(),
+		} {
+			if unknown {
+				response.Diagnostics.AddAttributeError(attr.AtName(name), "value is unknown", "unknown values are not permitted")
+			}
+		}
+
+		inconsistency := behaviour.Inconsistency{
+			Target:         target,
+			Reads:          inconsistency.Reads.ValueInt64(),
+			Duration:       inconsistency.Duration.ValueString(),
+			Mode:           behaviour.InconsistencyMode(inconsistency.Mode.ValueString()),
+			ReadAfterWrite: inconsistency.ReadAfterWrite.ValueBool(),
+		}
+		if err := inconsistency.Validate(); err != nil {
+			response.Diagnostics.AddAttributeError(attr, "invalid inconsistency", err.Error())
+			continue
+		}
+		behaviours.Inconsistencies = append(behaviours.Inconsistencies, inconsistency)
+	}
+
+	m.behaviours = behaviours
+}
+
+func parseTarget(data targetData, attr path.Path) (behaviour.Target, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	for name, value := range map[string]types.String{
+		"resource_type": data.ResourceType,
+		"id":            data.ID,
+		"match":         data.Match,
+	} {
+		if value.IsUnknown() {
+			diags.Append(diag.NewAttributeErrorDiagnostic(attr.AtName(name), "value is unknown", "unknown values are not permitted"))
+		}
+	}
+
+	return behaviour.Target{
+		ResourceType: data.ResourceType.ValueString(),
+		ID:           data.ID.ValueString(),
+		Match:        behaviour.Match(data.Match.ValueString()),
+	}, diags
+}
+
+func parseSchedule(data scheduleData, attr path.Path) (behaviour.Schedule, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	for name, unknown := range map[string]bool{
+		"on_call":     data.OnCall.IsUnknown(),
+		"first_calls": data.FirstCalls.IsUnknown(),
+		"probability": data.Probability.IsUnknown(),
+		"seed":        data.Seed.IsUnknown(),
+	} {
+		if unknown {
+			diags.Append(diag.NewAttributeErrorDiagnostic(attr.AtName(name), "value is unknown", "unknown values are not permitted"))
+		}
+	}
+
+	schedule := behaviour.Schedule{
+		OnCall:     data.OnCall.ValueInt64(),
+		FirstCalls: data.FirstCalls.ValueInt64(),
+		Seed:       data.Seed.ValueInt64(),
+	}
+	if !data.Probability.IsNull() {
+		schedule.Probability = data.Probability.ValueFloat64Pointer()
+	}
+	return schedule, diags
+}
+
+func parseDiagnostic(data diagnosticData, attr path.Path) (behaviour.Diagnostic, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	for name, value := range map[string]types.String{
+		"severity":  data.Severity,
+		"summary":   data.Summary,
+		"detail":    data.Detail,
+		"attribute": data.Attribute,
+	} {
+		if value.IsUnknown() {
+			diags.Append(diag.NewAttributeErrorDiagnostic(attr.AtName(name), "value is unknown", "unknown values are not permitted"))
+		}
+	}
+
+	return behaviour.Diagnostic{
+		Severity:  behaviour.Severity(data.Severity.ValueString()),
+		Summary:   data.Summary.ValueString(),
+		Detail:    data.Detail.ValueString(),
+		Attribute: data.Attribute.ValueString(),
+	}, diags
 }
 
-func parseStringList(ctx context.Context, value types.List, attr string) ([]string, diag.Diagnostics) {
+// parseValues reads a map of JSON strings, each holding a value in the same
+// format the resources are written in.
+func parseValues(ctx context.Context, value types.Map, attr path.Path) (map[string]data.Value, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
 	if value.IsNull() {
@@ -167,7 +674,39 @@ func parseStringList(ctx context.Context, value types.List, attr string) ([]stri
 	}
 
 	if value.IsUnknown() {
-		diags.Append(diag.NewAttributeErrorDiagnostic(path.Root(attr), "value is unknown", "unknown values are not permitted"))
+		diags.Append(diag.NewAttributeErrorDiagnostic(attr, "value is unknown", "unknown values are not permitted"))
+		return nil, diags
+	}
+
+	var elements map[string]types.String
+	diags.Append(value.ElementsAs(ctx, &elements, false)...)
+
+	values := make(map[string]data.Value, len(elements))
+	for name, element := range elements {
+		if element.IsUnknown() {
+			diags.Append(diag.NewAttributeErrorDiagnostic(attr.AtMapKey(name), "value is unknown", "unknown values are not permitted"))
+			continue
+		}
+
+		var value data.Value
+		if err := json.Unmarshal([]byte(element.ValueString()), &value); err != nil {
+			diags.Append(diag.NewAttributeErrorDiagnostic(attr.AtMapKey(name), "invalid value", err.Error()))
+			continue
+		}
+		values[name] = value
+	}
+	return values, diags
+}
+
+func parseStringList(ctx context.Context, value types.List, attr path.Path) ([]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if value.IsNull() {
+		return nil, diags
+	}
+
+	if value.IsUnknown() {
+		diags.Append(diag.NewAttributeErrorDiagnostic(attr, "value is unknown", "unknown values are not permitted"))
 		return nil, diags
 	}
 
@@ -177,12 +716,12 @@ func parseStringList(ctx context.Context, value types.List, attr string) ([]stri
 	var elements []string
 	for ix, element := range types {
 		if element.IsNull() {
-			diags.Append(diag.NewAttributeErrorDiagnostic(path.Root(attr).AtListIndex(ix), "element is null", "null values are not permitted"))
+			diags.Append(diag.NewAttributeErrorDiagnostic(attr.AtListIndex(ix), "element is null", "null values are not permitted"))
 			continue
 		}
 
 		if element.IsUnknown() {
-			diags.Append(diag.NewAttributeErrorDiagnostic(path.Root(attr).AtListIndex(ix), "element is null", "null values are not permitted"))
+			diags.Append(diag.NewAttributeErrorDiagnostic(attr.AtListIndex(ix), "element is null", "null values are not permitted"))
 			continue
 		}
 
@@ -204,11 +743,10 @@ func (m *tfcoremockProvider) Resources(ctx context.Context) []func() tfresource.
 				Name:           "tfcoremock_complex_resource",
 				InternalSchema: complex.Schema(3),
 				Client:         m.client,
-				FailOnDelete:   m.failOnDelete,
-				FailOnCreate:   m.failOnCreate,
-				FailOnRead:     m.failOnRead,
-				FailOnUpdate:   m.failOnUpdate,
-				DeferChanges:   m.deferChanges,
+				Behaviours:     m.behaviours,
+				Faults:         m.faults,
+				Counter:        m.counter,
+				Revisions:      m.revisions,
 			}
 		},
 		func() tfresource.Resource {
@@ -216,11 +754,10 @@ func (m *tfcoremockProvider) Resources(ctx context.Context) []func() tfresource.
 				Name:           "tfcoremock_simple_resource",
 				InternalSchema: simple.Schema,
 				Client:         m.client,
-				FailOnDelete:   m.failOnDelete,
-				FailOnCreate:   m.failOnCreate,
-				FailOnRead:     m.failOnRead,
-				FailOnUpdate:   m.failOnUpdate,
-				DeferChanges:   m.deferChanges,
+				Behaviours:     m.behaviours,
+				Faults:         m.faults,
+				Counter:        m.counter,
+				Revisions:      m.revisions,
 			}
 		},
 	}
@@ -250,11 +787,10 @@ func (m *tfcoremockProvider) Resources(ctx context.Context) []func() tfresource.
 				Name:           resourceName,
 				InternalSchema: resourceSchema,
 				Client:         m.client,
-				FailOnDelete:   m.failOnDelete,
-				FailOnCreate:   m.failOnCreate,
-				FailOnRead:     m.failOnRead,
-				FailOnUpdate:   m.failOnUpdate,
-				DeferChanges:   m.deferChanges,
+				Behaviours:     m.behaviours.Merge(resourceSchema.Behaviours),
+				Faults:         m.faults,
+				Counter:        m.counter,
+				Revisions:      m.revisions,
 			}
 		})
 	}
@@ -269,6 +805,9 @@ func (m *tfcoremockProvider) DataSources(ctx context.Context) []func() datasourc
 				Name:           "tfcoremock_complex_resource",
 				InternalSchema: complex.Schema(3),
 				Client:         m.client,
+				Behaviours:     m.behaviours,
+				Faults:         m.faults,
+				Counter:        m.counter,
 			}
 		},
 		func() datasource.DataSource {
@@ -276,6 +815,9 @@ func (m *tfcoremockProvider) DataSources(ctx context.Context) []func() datasourc
 				Name:           "tfcoremock_simple_resource",
 				InternalSchema: simple.Schema,
 				Client:         m.client,
+				Behaviours:     m.behaviours,
+				Faults:         m.faults,
+				Counter:        m.counter,
 			}
 		},
 	}
@@ -305,6 +847,9 @@ func (m *tfcoremockProvider) DataSources(ctx context.Context) []func() datasourc
 				Name:           datasourceName,
 				InternalSchema: datasourceSchema,
 				Client:         m.client,
+				Behaviours:     m.behaviours.Merge(datasourceSchema.Behaviours),
+				Faults:         m.faults,
+				Counter:        m.counter,
 			}
 		})
 	}
@@ -318,12 +863,18 @@ func (m *tfcoremockProvider) Actions(ctx context.Context) []func() action.Action
 			return resource.Action{
 				Name:           "tfcoremock_complex_resource",
 				InternalSchema: complex.Schema(3),
+				Behaviours:     m.behaviours,
+				Faults:         m.faults,
+				Counter:        m.counter,
 			}
 		},
 		func() action.Action {
 			return resource.Action{
 				Name:           "tfcoremock_simple_resource",
 				InternalSchema: simple.Schema,
+				Behaviours:     m.behaviours,
+				Faults:         m.faults,
+				Counter:        m.counter,
 			}
 		},
 	}
@@ -352,6 +903,9 @@ func (m *tfcoremockProvider) Actions(ctx context.Context) []func() action.Action
 			return resource.Action{
 				Name:           actionName,
 				InternalSchema: actionSchema,
+				Behaviours:     m.behaviours.Merge(actionSchema.Behaviours),
+				Faults:         m.faults,
+				Counter:        m.counter,
 			}
 		})
 	}
@@ -359,6 +913,59 @@ func (m *tfcoremockProvider) Actions(ctx context.Context) []func() action.Action
 	return actions
 }
 
+func (m *tfcoremockProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	ephemeralResources := []func() ephemeral.EphemeralResource{
+		func() ephemeral.EphemeralResource {
+			return resource.EphemeralResource{
+				Name:           "tfcoremock_complex_resource",
+				InternalSchema: complex.Schema(3),
+				Counter:        m.counter,
+				Log:            m.ephemeralLog,
+			}
+		},
+		func() ephemeral.EphemeralResource {
+			return resource.EphemeralResource{
+				Name:           "tfcoremock_simple_resource",
+				InternalSchema: simple.Schema,
+				Counter:        m.counter,
+				Log:            m.ephemeralLog,
+			}
+		},
+	}
+
+	schemas, err := m.reader.Read()
+	if err != nil {
+		// This isn't ideal, as the plugin will tell the user this is a problem
+		// with the provider. It's not though, this means the provided dynamic
+		// resources file either wasn't valid JSON or didn't match our schema.
+		//
+		// We don't have a way to raise an error through the plugin at this
+		// point in time though, so the only thing we can really do is panic.
+		//
+		// We add a lot of context to this panic to try and make the caller
+		// realise exactly what the problem is.
+		panic(fmt.Sprintf("The tfcoremock provider either failed to parse or failed to validate your dynamic resources file. "+
+			"Terraform will say this is a problem in the provider, but in this case it is a problem in your dynamic resources file. "+
+			"We have the following error from the parser, hopefully it provides additional context about the problem but these errors are not always helpful."+
+			"\n\n%s\n", err.Error()))
+	}
+
+	for name, schema := range schemas {
+		ephemeralResourceName := name
+		ephemeralResourceSchema := schema
+		ephemeralResources = append(ephemeralResources, func() ephemeral.EphemeralResource {
+			return resource.EphemeralResource{
+				Name:           ephemeralResourceName,
+				InternalSchema: ephemeralResourceSchema,
+				Counter:        m.counter,
+				Log:            m.ephemeralLog,
+			}
+		})
+	}
+
+	return ephemeralResources
+}
+
 func (m *tfcoremockProvider) ListResources(ctx context.Context) []func() list.ListResource {
 	listResources := []func() list.ListResource{
 		func() list.ListResource {
@@ -366,6 +973,10 @@ func (m *tfcoremockProvider) ListResources(ctx context.Context) []func() list.Li
 				Name:           "tfcoremock_complex_resource",
 				InternalSchema: complex.Schema(3),
 				Client:         m.client,
+				Behaviours:     m.behaviours,
+				Faults:         m.faults,
+				Counter:        m.counter,
+				Deferred:       m.deferred,
 			}
 		},
 		func() list.ListResource {
@@ -373,6 +984,10 @@ func (m *tfcoremockProvider) ListResources(ctx context.Context) []func() list.Li
 				Name:           "tfcoremock_simple_resource",
 				InternalSchema: simple.Schema,
 				Client:         m.client,
+				Behaviours:     m.behaviours,
+				Faults:         m.faults,
+				Counter:        m.counter,
+				Deferred:       m.deferred,
 			}
 		},
 	}
@@ -402,6 +1017,10 @@ func (m *tfcoremockProvider) ListResources(ctx context.Context) []func() list.Li
 				Name:           listResourceName,
 				InternalSchema: listResourceSchema,
 				Client:         m.client,
+				Behaviours:     m.behaviours.Merge(listResourceSchema.Behaviours),
+				Faults:         m.faults,
+				Counter:        m.counter,
+				Deferred:       m.deferred,
 			}
 		})
 	}
@@ -409,6 +1028,56 @@ func (m *tfcoremockProvider) ListResources(ctx context.Context) []func() list.Li
 	return listResources
 }
 
+func (m *tfcoremockProvider) Functions(ctx context.Context) []func() function.Function {
+	functions := []func() function.Function{
+		func() function.Function {
+			return resource.EchoFunction{}
+		},
+		func() function.Function {
+			return resource.FailFunction{}
+		},
+		func() function.Function {
+			return resource.SensitiveEchoFunction{}
+		},
+		func() function.Function {
+			return resource.UnknownPassthroughFunction{}
+		},
+		func() function.Function {
+			return resource.VariadicCountFunction{}
+		},
+	}
+
+	definitions, err := m.reader.ReadFunctions()
+	if err != nil {
+		// This isn't ideal, as the plugin will tell the user this is a problem
+		// with the provider. It's not though, this means the provided dynamic
+		// resources file either wasn't valid JSON or didn't match our schema.
+		//
+		// We don't have a way to raise an error through the plugin at this
+		// point in time though, so the only thing we can really do is panic.
+		//
+		// We add a lot of context to this panic to try and make the caller
+		// realise exactly what the problem is.
+		panic(fmt.Sprintf("The tfcoremock provider either failed to parse or failed to validate your dynamic resources file. "+
+			"Terraform will say this is a problem in the provider, but in this case it is a problem in your dynamic resources file. "+
+			"We have the following error from the parser, hopefully it provides additional context about the problem but these errors are not always helpful."+
+			"\n\n%s\n", err.Error()))
+	}
+
+	for name, definition := range definitions {
+		functionName := name
+		functionDefinition := definition
+		functions = append(functions, func() function.Function {
+			return resource.Function{
+				Name:             functionName,
+				InternalFunction: functionDefinition,
+			}
+		})
+	}
+
+	return functions
+}
+
 func (m *tfcoremockProvider) Schema(ctx context.Context, request provider.SchemaRequest, response *provider.SchemaResponse) {
 	response.Schema = provider_schema.Schema{
 		Description:         description,
@@ -429,6 +1098,11 @@ func (m *tfcoremockProvider) Schema(ctx context.Context, request provider.Schema
 				MarkdownDescription: "If set to true the provider will rely only on the Terraform state file to load managed resources and will not write anything to disk. Defaults to `false`.",
 				Optional:            true,
 			},
+			"defer_on_unknown_config": provider_schema.BoolAttribute{
+				Optional:            true,
+				Description:         "If set to true, the provider defers every resource and data source when any of its own configuration is unknown, instead of raising an error. List resources return no results instead, as they can't be deferred. Defaults to `false`.",
+				MarkdownDescription: "If set to true, the provider defers every resource and data source when any of its own configuration is unknown, instead of raising an error. List resources return no results instead, as they can't be deferred. Defaults to `false`.",
+			},
 			"fail_on_create": provider_schema.ListAttribute{
 				ElementType:         types.StringType,
 				Optional:            true,
@@ -453,6 +1127,30 @@ func (m *tfcoremockProvider) Schema(ctx context.Context, request provider.Schema
 				Description:         "If set, any resources with an ID in this list will fail during the delete phase.",
 				MarkdownDescription: "If set, any resources with an ID in this list will fail during the delete phase.",
 			},
+			"fail_on_import": provider_schema.ListAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				Description:         "If set, any resources with an ID in this list will fail when they are imported.",
+				MarkdownDescription: "If set, any resources with an ID in this list will fail when they are imported.",
+			},
+			"fail_on_read_data_source": provider_schema.ListAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				Description:         "If set, any data sources with an ID in this list will fail when they are read.",
+				MarkdownDescription: "If set, any data sources with an ID in this list will fail when they are read.",
+			},
+			"fail_on_list": provider_schema.ListAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				Description:         "If set, any list resources with a type in this list will fail when they are queried.",
+				MarkdownDescription: "If set, any list resources with a type in this list will fail when they are queried.",
+			},
+			"fail_on_invoke": provider_schema.ListAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				Description:         "If set, any actions with a type in this list will fail when they are invoked.",
+				MarkdownDescription: "If set, any actions with a type in this list will fail when they are invoked.",
+			},
 			"defer_changes": provider_schema.ListAttribute{
 				ElementType:         types.StringType,
 				Optional:            true,
@@ -460,9 +1158,234 @@ func (m *tfcoremockProvider) Schema(ctx context.Context, request provider.Schema
 				MarkdownDescription: "If set, any resources with an ID in this list will have any changes deferred during the plan phase.",
 			},
 		},
+		Blocks: map[string]provider_schema.Block{
+			"crash": provider_schema.ListNestedBlock{
+				Description:         "Forces the provider to crash during the specified operations on any matching resources, so Terraform's handling of plugin crashes can be tested. Use `on_call` or `first_calls` so later runs can recover.",
+				MarkdownDescription: "Forces the provider to crash during the specified operations on any matching resources, so Terraform's handling of plugin crashes can be tested. Use `on_call` or `first_calls` so later runs can recover.",
+				NestedObject: provider_schema.NestedBlockObject{
+					Attributes: scheduleAttributes(targetAttributes(map[string]provider_schema.Attribute{
+						"operations": provider_schema.ListAttribute{
+							ElementType:         types.StringType,
+							Required:            true,
+							Description:         "The operations that should crash. Accepts the same values as the `operations` attribute of the `failure` block.",
+							MarkdownDescription: "The operations that should crash. Accepts the same values as the `operations` attribute of the `failure` block.",
+						},
+						"mode": provider_schema.StringAttribute{
+							Optional:            true,
+							Description:         "How the provider should crash. Valid values are `panic`, which panics while handling the operation, and `exit`, which exits the provider process immediately. Defaults to `panic`.",
+							MarkdownDescription: "How the provider should crash. Valid values are `panic`, which panics while handling the operation, and `exit`, which exits the provider process immediately. Defaults to `panic`.",
+						},
+						"exit_code": provider_schema.Int64Attribute{
+							Optional:            true,
+							Description:         "The exit code of the provider process when `mode` is `exit`. Defaults to `1`.",
+							MarkdownDescription: "The exit code of the provider process when `mode` is `exit`. Defaults to `1`.",
+						},
+					})),
+				},
+			},
+			"drift": provider_schema.ListNestedBlock{
+				Description:         "Changes any matching resources when they are refreshed, as if they had been changed outside of Terraform. The changes are written back to the resource directory, so later refreshes see them too.",
+				MarkdownDescription: "Changes any matching resources when they are refreshed, as if they had been changed outside of Terraform. The changes are written back to the resource directory, so later refreshes see them too.",
+				NestedObject: provider_schema.NestedBlockObject{
+					Attributes: scheduleAttributes(targetAttributes(map[string]provider_schema.Attribute{
+						"remove": provider_schema.BoolAttribute{
+							Optional:            true,
+							Description:         "If set to true, matching resources are removed entirely. Conflicts with `values`.",
+							MarkdownDescription: "If set to true, matching resources are removed entirely. Conflicts with `values`.",
+						},
+						"values": provider_schema.MapAttribute{
+							ElementType:         types.StringType,
+							Optional:            true,
+							Description:         "The new values for top-level attributes of matching resources, keyed by attribute name. Each value is a JSON string in the same format the resources are written in, for example `jsonencode({ string = \"drifted\" })`. Conflicts with `remove`.",
+							MarkdownDescription: "The new values for top-level attributes of matching resources, keyed by attribute name. Each value is a JSON string in the same format the resources are written in, for example `jsonencode({ string = \"drifted\" })`. Conflicts with `remove`.",
+						},
+					})),
+				},
+			},
+			"failure": provider_schema.ListNestedBlock{
+				Description:         "Forces any matching resources to fail during the specified operations. Unlike the `fail_on_*` attributes, resources can be matched by patterns over both their ID and their type.",
+				MarkdownDescription: "Forces any matching resources to fail during the specified operations. Unlike the `fail_on_*` attributes, resources can be matched by patterns over both their ID and their type.",
+				NestedObject: provider_schema.NestedBlockObject{
+					Attributes: diagnosticAttributes(scheduleAttributes(targetAttributes(map[string]provider_schema.Attribute{
+						"operations": provider_schema.ListAttribute{
+							ElementType:         types.StringType,
+							Required:            true,
+							Description:         "The operations that should fail. Valid values are `create`, `read`, `update`, `delete`, `plan`, `plan_create`, `plan_update`, `plan_replace`, `plan_delete`, `import`, `read_data_source`, `list`, and `invoke`. The `plan` operation matches every plan, including plans that make no changes. Actions and list resources are matched by their type only, while the ID of a list resource is the `id` filter in its configuration.",
+							MarkdownDescription: "The operations that should fail. Valid values are `create`, `read`, `update`, `delete`, `plan`, `plan_create`, `plan_update`, `plan_replace`, `plan_delete`, `import`, `read_data_source`, `list`, and `invoke`. The `plan` operation matches every plan, including plans that make no changes. Actions and list resources are matched by their type only, while the ID of a list resource is the `id` filter in its configuration.",
+						},
+						"partial": provider_schema.BoolAttribute{
+							Optional:            true,
+							Description:         "If set to true, the resource is written and its new state returned before the failure is raised. Terraform will record the resource, marking it as tainted after a failed create. Can only be used with the `create` and `update` operations. Defaults to `false`.",
+							MarkdownDescription: "If set to true, the resource is written and its new state returned before the failure is raised. Terraform will record the resource, marking it as tainted after a failed create. Can only be used with the `create` and `update` operations. Defaults to `false`.",
+						},
+						"after_results": provider_schema.Int64Attribute{
+							Optional:            true,
+							Description:         "If set, the failure is only raised after this many results have been returned by a list resource. Can only be used with the `list` operation. Defaults to `0`.",
+							MarkdownDescription: "If set, the failure is only raised after this many results have been returned by a list resource. Can only be used with the `list` operation. Defaults to `0`.",
+						},
+					}))),
+				},
+			},
+			"inconsistency": provider_schema.ListNestedBlock{
+				Description:         "Makes reads of any matching resources eventually consistent. After each matching create or update, refreshes return the previous version of the resource, or report it as missing, until the given number of reads have been made or the given duration has passed.",
+				MarkdownDescription: "Makes reads of any matching resources eventually consistent. After each matching create or update, refreshes return the previous version of the resource, or report it as missing, until the given number of reads have been made or the given duration has passed.",
+				NestedObject: provider_schema.NestedBlockObject{
+					Attributes: targetAttributes(map[string]provider_schema.Attribute{
+						"operations": provider_schema.ListAttribute{
+							ElementType:         types.StringType,
+							Optional:            true,
+							Description:         "The writes after which reads are inconsistent. Valid values are `create` and `update`. If unset, both are.",
+							MarkdownDescription: "The writes after which reads are inconsistent. Valid values are `create` and `update`. If unset, both are.",
+						},
+						"reads": provider_schema.Int64Attribute{
+							Optional:            true,
+							Description:         "The number of reads after each write that are inconsistent. At least one of `reads` and `duration` must be set, and if both are the inconsistency ends when the first runs out.",
+							MarkdownDescription: "The number of reads after each write that are inconsistent. At least one of `reads` and `duration` must be set, and if both are the inconsistency ends when the first runs out.",
+						},
+						"duration": provider_schema.StringAttribute{
+							Optional:            true,
+							Description:         "How long reads are inconsistent for after each write, for example `500ms` or `1m30s`.",
+							MarkdownDescription: "How long reads are inconsistent for after each write, for example `500ms` or `1m30s`.",
+						},
+						"mode": provider_schema.StringAttribute{
+							Optional:            true,
+							Description:         "What inconsistent reads return. Valid values are `stale`, which returns the version of the resource from before the write, and `not_found`, which reports the resource as missing. Stale reads after a create always report the resource as missing. Defaults to `stale`.",
+							MarkdownDescription: "What inconsistent reads return. Valid values are `stale`, which returns the version of the resource from before the write, and `not_found`, which reports the resource as missing. Stale reads after a create always report the resource as missing. Defaults to `stale`.",
+						},
+						"read_after_write": provider_schema.BoolAttribute{
+							Optional:            true,
+							Description:         "If set to true, matching creates and updates read the resource back and return what they read, so Terraform sees the inconsistent result of the apply. The read counts towards `reads`. Defaults to `false`.",
+							MarkdownDescription: "If set to true, matching creates and updates read the resource back and return what they read, so Terraform sees the inconsistent result of the apply. The read counts towards `reads`. Defaults to `false`.",
+						},
+					}),
+				},
+			},
+			"deferral": provider_schema.ListNestedBlock{
+				Description:         "Forces any matching resources to defer their changes during the plan phase, or during the other specified operations. Unlike the `defer_changes` attribute, resources can be matched by patterns over both their ID and their type.",
+				MarkdownDescription: "Forces any matching resources to defer their changes during the plan phase, or during the other specified operations. Unlike the `defer_changes` attribute, resources can be matched by patterns over both their ID and their type.",
+				NestedObject: provider_schema.NestedBlockObject{
+					Attributes: targetAttributes(map[string]provider_schema.Attribute{
+						"operations": provider_schema.ListAttribute{
+							ElementType:         types.StringType,
+							Optional:            true,
+							Description:         "The operations that should be deferred. Valid values are `plan`, `plan_create`, `plan_update`, `plan_replace`, `read`, `import`, and `read_data_source`. If unset, only plans are deferred.",
+							MarkdownDescription: "The operations that should be deferred. Valid values are `plan`, `plan_create`, `plan_update`, `plan_replace`, `read`, `import`, and `read_data_source`. If unset, only plans are deferred.",
+						},
+						"reason": provider_schema.StringAttribute{
+							Optional:            true,
+							Description:         "The reason given to Terraform for the deferral. Valid values are `resource_config_unknown`, `provider_config_unknown`, and `absent_prereq`. Defaults to `resource_config_unknown`.",
+							MarkdownDescription: "The reason given to Terraform for the deferral. Valid values are `resource_config_unknown`, `provider_config_unknown`, and `absent_prereq`. Defaults to `resource_config_unknown`.",
+						},
+						"when_unknown": provider_schema.BoolAttribute{
+							Optional:            true,
+							Description:         "If set to true, matching resources and data sources are only deferred when their configuration contains unknown values. Can only be used with plans and the `read_data_source` operation. Defaults to `false`.",
+							MarkdownDescription: "If set to true, matching resources and data sources are only deferred when their configuration contains unknown values. Can only be used with plans and the `read_data_source` operation. Defaults to `false`.",
+						},
+						"unknown_attributes": provider_schema.ListAttribute{
+							ElementType:         types.StringType,
+							Optional:            true,
+							Description:         "If set, matching resources and data sources are only deferred when one of these top-level attributes or blocks in their configuration is, or contains, an unknown value. Implies `when_unknown`.",
+							MarkdownDescription: "If set, matching resources and data sources are only deferred when one of these top-level attributes or blocks in their configuration is, or contains, an unknown value. Implies `when_unknown`.",
+						},
+					}),
+				},
+			},
+			"delay": provider_schema.ListNestedBlock{
+				Description:         "Slows down any matching resources during the specified operations. The delay ends early if Terraform cancels the operation, in which case the operation fails.",
+				MarkdownDescription: "Slows down any matching resources during the specified operations. The delay ends early if Terraform cancels the operation, in which case the operation fails.",
+				NestedObject: provider_schema.NestedBlockObject{
+					Attributes: scheduleAttributes(targetAttributes(map[string]provider_schema.Attribute{
+						"operations": provider_schema.ListAttribute{
+							ElementType:         types.StringType,
+							Optional:            true,
+							Description:         "The operations that should be delayed. Accepts the same values as the `operations` attribute of the `failure` block. If unset, every operation is delayed.",
+							MarkdownDescription: "The operations that should be delayed. Accepts the same values as the `operations` attribute of the `failure` block. If unset, every operation is delayed.",
+						},
+						"duration": provider_schema.StringAttribute{
+							Required:            true,
+							Description:         "How long each matching operation should be delayed for, for example `500ms` or `1m30s`.",
+							MarkdownDescription: "How long each matching operation should be delayed for, for example `500ms` or `1m30s`.",
+						},
+					})),
+				},
+			},
+		},
 	}
 }
 
+// targetAttributes adds the attributes shared by every behaviour block that
+// decide which resources the behaviour applies to.
+func targetAttributes(attributes map[string]provider_schema.Attribute) map[string]provider_schema.Attribute {
+	attributes["resource_type"] = provider_schema.StringAttribute{
+		Optional:            true,
+		Description:         "If set, only resources with a type matching this pattern are affected.",
+		MarkdownDescription: "If set, only resources with a type matching this pattern are affected.",
+	}
+	attributes["id"] = provider_schema.StringAttribute{
+		Optional:            true,
+		Description:         "If set, only resources with an ID matching this pattern are affected.",
+		MarkdownDescription: "If set, only resources with an ID matching this pattern are affected.",
+	}
+	attributes["match"] = provider_schema.StringAttribute{
+		Optional:            true,
+		Description:         "How the `id` and `resource_type` patterns are matched. Valid values are `exact`, `glob`, and `regex`. Defaults to `glob`.",
+		MarkdownDescription: "How the `id` and `resource_type` patterns are matched. Valid values are `exact`, `glob`, and `regex`. Defaults to `glob`.",
+	}
+	return attributes
+}
+
+// scheduleAttributes adds the attributes that decide which invocations of the
+// targeted operations a behaviour should be applied to.
+func scheduleAttributes(attributes map[string]provider_schema.Attribute) map[string]provider_schema.Attribute {
+	attributes["on_call"] = provider_schema.Int64Attribute{
+		Optional:            true,
+		Description:         "If set, the behaviour only applies to the Nth invocation of the operation for each matching resource. Invocations are counted across runs of the provider in a file next to the resource directory.",
+		MarkdownDescription: "If set, the behaviour only applies to the Nth invocation of the operation for each matching resource. Invocations are counted across runs of the provider in a file next to the resource directory.",
+	}
+	attributes["first_calls"] = provider_schema.Int64Attribute{
+		Optional:            true,
+		Description:         "If set, the behaviour only applies to the first N invocations of the operation for each matching resource. Conflicts with `on_call`.",
+		MarkdownDescription: "If set, the behaviour only applies to the first N invocations of the operation for each matching resource. Conflicts with `on_call`.",
+	}
+	attributes["probability"] = provider_schema.Float64Attribute{
+		Optional:            true,
+		Description:         "If set, the behaviour only applies to each invocation with this probability. The outcome for each invocation is fixed by the `seed`.",
+		MarkdownDescription: "If set, the behaviour only applies to each invocation with this probability. The outcome for each invocation is fixed by the `seed`.",
+	}
+	attributes["seed"] = provider_schema.Int64Attribute{
+		Optional:            true,
+		Description:         "The seed used to decide the outcome when `probability` is set. Defaults to `0`.",
+		MarkdownDescription: "The seed used to decide the outcome when `probability` is set. Defaults to `0`.",
+	}
+	return attributes
+}
+
+// diagnosticAttributes adds the attributes that customise the diagnostic
+// returned by a failure.
+func diagnosticAttributes(attributes map[string]provider_schema.Attribute) map[string]provider_schema.Attribute {
+	attributes["severity"] = provider_schema.StringAttribute{
+		Optional:            true,
+		Description:         "The severity of the diagnostic. Valid values are `error` and `warning`. A `warning` does not stop the operation from succeeding. Defaults to `error`.",
+		MarkdownDescription: "The severity of the diagnostic. Valid values are `error` and `warning`. A `warning` does not stop the operation from succeeding. Defaults to `error`.",
+	}
+	attributes["summary"] = provider_schema.StringAttribute{
+		Optional:            true,
+		Description:         "The summary of the diagnostic. Defaults to a summary describing the failed operation.",
+		MarkdownDescription: "The summary of the diagnostic. Defaults to a summary describing the failed operation.",
+	}
+	attributes["detail"] = provider_schema.StringAttribute{
+		Optional:            true,
+		Description:         "The detail of the diagnostic. Defaults to `forced failure`.",
+		MarkdownDescription: "The detail of the diagnostic. Defaults to `forced failure`.",
+	}
+	attributes["attribute"] = provider_schema.StringAttribute{
+		Optional:            true,
+		Description:         "If set, the diagnostic is attached to the attribute at this path, for example `list[0].string` or `map[\"key\"]`.",
+		MarkdownDescription: "If set, the diagnostic is attached to the attribute at this path, for example `list[0].string` or `map[\"key\"]`.",
+	}
+	return attributes
+}
+
 func New(version string) func() provider.Provider {
 	return func() provider.Provider {
 		dynamicResourcesPath := "dynamic_resources.json"
@@ -473,6 +1396,7 @@ func New(version string) func() provider.Provider {
 		return &tfcoremockProvider{
 			version: version,
 			reader:  dynamic.FileReader{File: dynamicResourcesPath},
+			faults:  faultsReader(),
 		}
 	}
 }
@@ -482,6 +1406,16 @@ func NewForTesting(version string, resources string) func() provider.Provider {
 		return &tfcoremockProvider{
 			version: version,
 			reader:  dynamic.StringReader{Data: resources},
+			faults:  faultsReader(),
 		}
 	}
 }
+
+// faultsReader returns a reader for the faults file named by the
+// TFCOREMOCK_FAULTS_FILE environment variable, or nil if it isn't set.
+func faultsReader() behaviour.Reader {
+	if faultsPath := os.Getenv(faultsPathEnvVarName); len(faultsPath) > 0 {
+		return behaviour.FileReader{File: faultsPath}
+	}
+	return nil
+}
@@ -44,6 +44,22 @@ func CleanupTestingDirectories(t *testing.T) func() {
 	}
 }
 
+func CleanupInvocationCounts(t *testing.T) func() {
+	return func() {
+		if err := os.Remove("terraform.resource.invocations.json"); err != nil && !os.IsNotExist(err) {
+			t.Fatalf("could not remove the invocation counts: %v", err)
+		}
+	}
+}
+
+func CleanupEphemeralLog(t *testing.T) func() {
+	return func() {
+		if err := os.Remove("terraform.resource.ephemeral.log"); err != nil && !os.IsNotExist(err) {
+			t.Fatalf("could not remove the ephemeral log: %v", err)
+		}
+	}
+}
+
 func SaveResourceId(name string, id *string) resource.TestCheckFunc {
 	return func(state *terraform.State) error {
 		module := state.RootModule()
@@ -7,11 +7,16 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
 	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 )
 
@@ -260,6 +265,234 @@ func TestAccDynamicResourceWithRequiresReplace(t *testing.T) {
 	})
 }
 
+func TestAccDynamicResourceWithKnownAfterApply(t *testing.T) {
+	t.Cleanup(CleanupTestingDirectories(t))
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: ProviderFactories(LoadFile(t, "testdata/dynamic_known_after_apply/dynamic_resources.json")),
+		Steps: []resource.TestStep{
+			{
+				Config: LoadFile(t, "testdata/dynamic_known_after_apply/create/main.tf"),
+				Check:  resource.TestCheckResourceAttr("tfcoremock_dynamic_resource.test", "computed", "computed"),
+			},
+			{
+				Config: LoadFile(t, "testdata/dynamic_known_after_apply/update/main.tf"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectUnknownValue("tfcoremock_dynamic_resource.test", tfjsonpath.New("computed")),
+					},
+				},
+				Check: resource.TestCheckResourceAttr("tfcoremock_dynamic_resource.test", "computed", "computed"),
+			},
+			{
+				Config: LoadFile(t, "testdata/dynamic/delete/main.tf"),
+			},
+		},
+	})
+}
+
+func TestAccDynamicResourceWithGenerators(t *testing.T) {
+	t.Cleanup(CleanupTestingDirectories(t))
+	t.Cleanup(CleanupInvocationCounts(t))
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: ProviderFactories(LoadFile(t, "testdata/dynamic_generators/dynamic_resources.json")),
+		Steps: []resource.TestStep{
+			{
+				Config: LoadFile(t, "testdata/dynamic_generators/create/main.tf"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("tfcoremock_dynamic_resource.test", "uuid", regexp.MustCompile("^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$")),
+					resource.TestMatchResourceAttr("tfcoremock_dynamic_resource.test", "updated_at", regexp.MustCompile("^[0-9]{4}-[0-9]{2}-[0-9]{2}T[0-9]{2}:[0-9]{2}:[0-9]{2}Z$")),
+					resource.TestCheckResourceAttr("tfcoremock_dynamic_resource.test", "version", "1"),
+					resource.TestMatchResourceAttr("tfcoremock_dynamic_resource.test", "etag", regexp.MustCompile("^[a-zA-Z0-9]{8}$")),
+					resource.TestMatchResourceAttr("tfcoremock_dynamic_resource.test", "port", regexp.MustCompile("^[0-9]{4,5}$"))),
+			},
+			{
+				Config: LoadFile(t, "testdata/dynamic_generators/update/main.tf"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectUnknownValue("tfcoremock_dynamic_resource.test", tfjsonpath.New("updated_at")),
+						plancheck.ExpectUnknownValue("tfcoremock_dynamic_resource.test", tfjsonpath.New("version")),
+					},
+				},
+				Check: resource.TestCheckResourceAttr("tfcoremock_dynamic_resource.test", "version", "2"),
+			},
+			{
+				Config: LoadFile(t, "testdata/dynamic/delete/main.tf"),
+			},
+		},
+	})
+}
+
+func TestAccDynamicResourceWithTemplates(t *testing.T) {
+	t.Cleanup(CleanupTestingDirectories(t))
+	t.Cleanup(CleanupInvocationCounts(t))
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: ProviderFactories(LoadFile(t, "testdata/dynamic_templates/dynamic_resources.json")),
+		Steps: []resource.TestStep{
+			{
+				Config: LoadFile(t, "testdata/dynamic_templates/create/main.tf"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectKnownValue("tfcoremock_dynamic_resource.test", tfjsonpath.New("fqdn"), knownvalue.StringExact("hello.example.com")),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("tfcoremock_dynamic_resource.test", "arn", "arn:mock:my-resource"),
+					resource.TestCheckResourceAttr("tfcoremock_dynamic_resource.test", "fqdn", "hello.example.com"),
+					resource.TestCheckResourceAttr("tfcoremock_dynamic_resource.test", "label", "v1-hello")),
+			},
+			{
+				Config: LoadFile(t, "testdata/dynamic_templates/update/main.tf"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						// The version regenerates on update, so the label
+						// that refers to it isn't known until the apply.
+						plancheck.ExpectUnknownValue("tfcoremock_dynamic_resource.test", tfjsonpath.New("label")),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("tfcoremock_dynamic_resource.test", "arn", "arn:mock:my-resource"),
+					resource.TestCheckResourceAttr("tfcoremock_dynamic_resource.test", "fqdn", "world.example.com"),
+					resource.TestCheckResourceAttr("tfcoremock_dynamic_resource.test", "label", "v2-world")),
+			},
+			{
+				Config: LoadFile(t, "testdata/dynamic/delete/main.tf"),
+			},
+		},
+	})
+}
+
+func TestAccDynamicResourceWithStateUpgrades(t *testing.T) {
+	t.Cleanup(CleanupTestingDirectories(t))
+	upgraded := ProviderFactories(LoadFile(t, "testdata/dynamic_upgrade/v2/dynamic_resources.json"))
+	resource.Test(t, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				ProtoV6ProviderFactories: ProviderFactories(LoadFile(t, "testdata/dynamic_upgrade/v0/dynamic_resources.json")),
+				Config:                   LoadFile(t, "testdata/dynamic_upgrade/v0/main.tf"),
+			},
+			{
+				// The upgraded state matches the new configuration, so there
+				// is nothing to change.
+				ProtoV6ProviderFactories: upgraded,
+				Config:                   LoadFile(t, "testdata/dynamic_upgrade/v2/main.tf"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("tfcoremock_dynamic_resource.test", "title", "hello"),
+					resource.TestCheckResourceAttr("tfcoremock_dynamic_resource.test", "zone", "zone-a"),
+					resource.TestCheckResourceAttr("tfcoremock_dynamic_resource.test", "endpoint.0.port", "8080")),
+			},
+			{
+				ProtoV6ProviderFactories: upgraded,
+				Config:                   LoadFile(t, "testdata/dynamic/delete/main.tf"),
+			},
+		},
+	})
+}
+
+func TestAccMoveSimpleResourceToDynamicResource(t *testing.T) {
+	t.Cleanup(CleanupTestingDirectories(t))
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0), // moves between resource types
+		},
+		ProtoV6ProviderFactories: ProviderFactories(LoadFile(t, "testdata/moved/dynamic_resources.json")),
+		Steps: []resource.TestStep{
+			{
+				Config: LoadFile(t, "testdata/moved/create/main.tf"),
+			},
+			{
+				// The moved state matches the new configuration, so there is
+				// nothing to change.
+				Config: LoadFile(t, "testdata/moved/move/main.tf"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("tfcoremock_dynamic_resource.test", "id", "my-moved-resource"),
+					resource.TestCheckResourceAttr("tfcoremock_dynamic_resource.test", "name", "hello"),
+					resource.TestCheckResourceAttr("tfcoremock_dynamic_resource.test", "total", "42")),
+			},
+			{
+				Config: LoadFile(t, "testdata/dynamic/delete/main.tf"),
+			},
+		},
+	})
+}
+
+func TestAccDynamicResourceWithWriteOnlyAttribute(t *testing.T) {
+	t.Cleanup(CleanupTestingDirectories(t))
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0), // write-only attributes
+		},
+		ProtoV6ProviderFactories: ProviderFactories(LoadFile(t, "testdata/dynamic_write_only/dynamic_resources.json")),
+		Steps: []resource.TestStep{
+			{
+				Config: LoadFile(t, "testdata/dynamic_write_only/create/main.tf"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("tfcoremock_dynamic_resource.test", "name", "hello"),
+					resource.TestCheckNoResourceAttr("tfcoremock_dynamic_resource.test", "password"),
+					func(state *terraform.State) error {
+						data, err := os.ReadFile("terraform.resource/my-write-only-resource.json")
+						if err != nil {
+							return err
+						}
+						if strings.Contains(string(data), "secret") {
+							return errors.New("expected the write-only value to be missing from the resource directory")
+						}
+						return nil
+					}),
+			},
+			{
+				Config: LoadFile(t, "testdata/dynamic/delete/main.tf"),
+			},
+		},
+	})
+}
+
+func TestAccDynamicResourceWithValidators(t *testing.T) {
+	t.Cleanup(CleanupTestingDirectories(t))
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: ProviderFactories(LoadFile(t, "testdata/dynamic_validators/dynamic_resources.json")),
+		Steps: []resource.TestStep{
+			{
+				Config:      LoadFile(t, "testdata/dynamic_validators/invalid/main.tf"),
+				ExpectError: regexp.MustCompile("names must be lowercase"),
+			},
+			{
+				Config: LoadFile(t, "testdata/dynamic_validators/create/main.tf"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("tfcoremock_dynamic_resource.test", "name", "hello"),
+					resource.TestCheckResourceAttr("tfcoremock_dynamic_resource.test", "port", "8080")),
+			},
+			{
+				Config: LoadFile(t, "testdata/dynamic/delete/main.tf"),
+			},
+		},
+	})
+}
+
+func TestAccDynamicDataSourceWithValidators(t *testing.T) {
+	t.Cleanup(CleanupTestingDirectories(t))
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: ProviderFactories(LoadFile(t, "testdata/dynamic_validators_datasource/dynamic_resources.json")),
+		Steps: []resource.TestStep{
+			{
+				// The data directory holds 0 for the integer, which is below
+				// the minimum.
+				Config:      LoadFile(t, "testdata/dynamic_validators_datasource/get/main.tf"),
+				ExpectError: regexp.MustCompile("value must be at least 1, got: 0"),
+			},
+		},
+	})
+}
+
 func TestAccMultipleDynamicResources(t *testing.T) {
 	t.Cleanup(CleanupTestingDirectories(t))
 	resource.Test(t, resource.TestCase{
@@ -297,6 +530,25 @@ func TestAccDynamicResourceWithDataSource(t *testing.T) {
 	})
 }
 
+func TestAccDynamicResourceWithBehaviours(t *testing.T) {
+	t.Cleanup(CleanupTestingDirectories(t))
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: ProviderFactories(LoadFile(t, "testdata/dynamic_behaviours/dynamic_resources.json")),
+		Steps: []resource.TestStep{
+			{
+				// The behaviours only apply to the dynamic resource, so the
+				// simple resource is unaffected despite matching the ID.
+				Config: LoadFile(t, "testdata/dynamic_behaviours/create/main.tf"),
+				Check:  resource.TestCheckResourceAttr("tfcoremock_simple_resource.resource", "id", "fail_simple"),
+			},
+			{
+				Config:      LoadFile(t, "testdata/dynamic_behaviours/update/main.tf"),
+				ExpectError: regexp.MustCompile("failed by dynamic resource"),
+			},
+		},
+	})
+}
+
 func TestAccSimpleDataSource(t *testing.T) {
 	t.Cleanup(CleanupTestingDirectories(t))
 	resource.Test(t, resource.TestCase{
@@ -356,6 +608,53 @@ func TestAccSimpleResourceWithDrift(t *testing.T) {
 	})
 }
 
+func TestAccSimpleResourceWithScriptedDrift(t *testing.T) {
+	t.Cleanup(CleanupTestingDirectories(t))
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: ProviderFactories(""),
+		Steps: []resource.TestStep{
+			{
+				Config:             LoadFile(t, "testdata/drift/values/main.tf"),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				RefreshState:       true,
+				Check:              resource.TestCheckResourceAttr("tfcoremock_simple_resource.test", "string", "drifted"),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: LoadFile(t, "testdata/simple/delete/main.tf"),
+			},
+		},
+	})
+}
+
+func TestAccSimpleResourceWithScriptedRemoval(t *testing.T) {
+	t.Cleanup(CleanupTestingDirectories(t))
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: ProviderFactories(""),
+		Steps: []resource.TestStep{
+			{
+				Config:             LoadFile(t, "testdata/drift/remove/main.tf"),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				RefreshState: true,
+				Check: func(state *terraform.State) error {
+					if _, ok := state.RootModule().Resources["tfcoremock_simple_resource.test"]; ok {
+						return errors.New("expected the resource to have been removed")
+					}
+					return nil
+				},
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: LoadFile(t, "testdata/simple/delete/main.tf"),
+			},
+		},
+	})
+}
+
 func TestAccSimpleResourceWithId(t *testing.T) {
 	t.Cleanup(CleanupTestingDirectories(t))
 	resource.Test(t, resource.TestCase{
@@ -395,6 +694,45 @@ func TestAccSimpleResourceWithDependsOn(t *testing.T) {
 	})
 }
 
+func TestAccSimpleResourceWithStaleRead(t *testing.T) {
+	t.Cleanup(CleanupTestingDirectories(t))
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: ProviderFactories(""),
+		Steps: []resource.TestStep{
+			{
+				Config: LoadFile(t, "testdata/inconsistency/create/main.tf"),
+			},
+			{
+				// The refresh after the update still sees the old value.
+				Config:             LoadFile(t, "testdata/inconsistency/stale/main.tf"),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: LoadFile(t, "testdata/simple/delete/main.tf"),
+			},
+		},
+	})
+}
+
+func TestAccSimpleResourceWithInconsistentResult(t *testing.T) {
+	t.Cleanup(CleanupTestingDirectories(t))
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: ProviderFactories(""),
+		Steps: []resource.TestStep{
+			{
+				Config: LoadFile(t, "testdata/inconsistency/create/main.tf"),
+			},
+			{
+				Config:      LoadFile(t, "testdata/inconsistency/read_after_write/main.tf"),
+				ExpectError: regexp.MustCompile("Provider produced inconsistent result after apply"),
+			},
+			{
+				Config: LoadFile(t, "testdata/simple/delete/main.tf"),
+			},
+		},
+	})
+}
+
 func TestAccSimpleResourceFailsOnRead(t *testing.T) {
 	t.Cleanup(CleanupTestingDirectories(t))
 	resource.Test(t, resource.TestCase{
@@ -408,6 +746,62 @@ func TestAccSimpleResourceFailsOnRead(t *testing.T) {
 	})
 }
 
+func TestAccSimpleDataSourceFailsOnRead(t *testing.T) {
+	t.Cleanup(CleanupTestingDirectories(t))
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: ProviderFactories(""),
+		Steps: []resource.TestStep{
+			{
+				Config:      LoadFile(t, "testdata/fail_on/read_data_source/main.tf"),
+				ExpectError: regexp.MustCompile("forced failure"),
+			},
+		},
+	})
+}
+
+func TestAccSimpleResourceFailsOnImport(t *testing.T) {
+	t.Cleanup(CleanupTestingDirectories(t))
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: ProviderFactories(""),
+		Steps: []resource.TestStep{
+			{
+				Config:      LoadFile(t, "testdata/fail_on/import/main.tf"),
+				ExpectError: regexp.MustCompile("forced failure"),
+			},
+		},
+	})
+}
+
+func TestAccSimpleResourceFailsFromFaultsFile(t *testing.T) {
+	t.Cleanup(CleanupTestingDirectories(t))
+
+	faults := filepath.Join(t.TempDir(), "faults.json")
+	t.Setenv(faultsPathEnvVarName, faults)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: ProviderFactories(""),
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					if err := os.WriteFile(faults, []byte(`{"failures": [{"operations": ["create"], "id": "iden", "summary": "from faults file"}]}`), 0644); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:      LoadFile(t, "testdata/faults_file/main.tf"),
+				ExpectError: regexp.MustCompile("from faults file"),
+			},
+			{
+				PreConfig: func() {
+					if err := os.Remove(faults); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: LoadFile(t, "testdata/faults_file/main.tf"),
+			},
+		},
+	})
+}
+
 func TestAccSimpleResourceFailsOnUpdate(t *testing.T) {
 	t.Cleanup(CleanupTestingDirectories(t))
 	resource.Test(t, resource.TestCase{
@@ -437,6 +831,116 @@ func TestAccSimpleResourceFailsOnCreate(t *testing.T) {
 	})
 }
 
+func TestAccSimpleResourceFailsOnPattern(t *testing.T) {
+	t.Cleanup(CleanupTestingDirectories(t))
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: ProviderFactories(""),
+		Steps: []resource.TestStep{
+			{
+				Config:      LoadFile(t, "testdata/fail_on/pattern/main.tf"),
+				ExpectError: regexp.MustCompile("forced failure"),
+			},
+		},
+	})
+}
+
+func TestAccSimpleResourceFailsOnSchedule(t *testing.T) {
+	t.Cleanup(CleanupTestingDirectories(t))
+	t.Cleanup(CleanupInvocationCounts(t))
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: ProviderFactories(""),
+		Steps: []resource.TestStep{
+			{
+				Config:      LoadFile(t, "testdata/fail_on/schedule/main.tf"),
+				ExpectError: regexp.MustCompile("forced failure"),
+			},
+			{
+				// The second attempt to create the resource should succeed.
+				Config: LoadFile(t, "testdata/fail_on/schedule/main.tf"),
+				Check:  resource.TestCheckResourceAttr("tfcoremock_simple_resource.resource", "id", "iden"),
+			},
+			{
+				Config: LoadFile(t, "testdata/simple/delete/main.tf"),
+			},
+		},
+	})
+}
+
+func TestAccSimpleResourceFailsWithDiagnostic(t *testing.T) {
+	t.Cleanup(CleanupTestingDirectories(t))
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: ProviderFactories(""),
+		Steps: []resource.TestStep{
+			{
+				Config:      LoadFile(t, "testdata/fail_on/diagnostic/main.tf"),
+				ExpectError: regexp.MustCompile("quota exceeded"),
+			},
+		},
+	})
+}
+
+func TestAccSimpleResourceWarnsOnFailure(t *testing.T) {
+	t.Cleanup(CleanupTestingDirectories(t))
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: ProviderFactories(""),
+		Steps: []resource.TestStep{
+			{
+				// Warnings should not stop any of the operations succeeding.
+				Config: LoadFile(t, "testdata/fail_on/warning/main.tf"),
+				Check:  resource.TestCheckResourceAttr("tfcoremock_simple_resource.resource", "id", "iden"),
+			},
+			{
+				Config: LoadFile(t, "testdata/simple/delete/main.tf"),
+			},
+		},
+	})
+}
+
+func TestAccSimpleResourceFailsPartially(t *testing.T) {
+	t.Cleanup(CleanupTestingDirectories(t))
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: ProviderFactories(""),
+		Steps: []resource.TestStep{
+			{
+				Config:      LoadFile(t, "testdata/fail_on/partial/create.tf"),
+				ExpectError: regexp.MustCompile("forced failure"),
+			},
+			{
+				// The failed create should have left a tainted resource in the
+				// state, so this will replace it.
+				Config: LoadFile(t, "testdata/fail_on/partial/recover.tf"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("tfcoremock_simple_resource.resource", plancheck.ResourceActionReplace),
+					},
+				},
+			},
+			{
+				Config: LoadFile(t, "testdata/simple/delete/main.tf"),
+			},
+		},
+	})
+}
+
+func TestAccSimpleResourceFailsOnPlanDelete(t *testing.T) {
+	t.Cleanup(CleanupTestingDirectories(t))
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: ProviderFactories(""),
+		Steps: []resource.TestStep{
+			{
+				Config: LoadFile(t, "testdata/fail_on/plan/create.tf"),
+			},
+			{
+				Config:      LoadFile(t, "testdata/fail_on/plan/delete.tf"),
+				ExpectError: regexp.MustCompile("cannot plan destroy"),
+			},
+			{
+				Config: LoadFile(t, "testdata/simple/delete/main.tf"),
+			},
+		},
+	})
+}
+
 func TestAccSimpleResourceFailsOnDelete(t *testing.T) {
 	t.Cleanup(CleanupTestingDirectories(t))
 	resource.Test(t, resource.TestCase{
@@ -458,6 +962,19 @@ func TestAccSimpleResourceFailsOnDelete(t *testing.T) {
 	})
 }
 
+func TestAccSimpleResourceWithDelay(t *testing.T) {
+	t.Cleanup(CleanupTestingDirectories(t))
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: ProviderFactories(""),
+		Steps: []resource.TestStep{
+			{
+				Config: LoadFile(t, "testdata/delay/main.tf"),
+				Check:  resource.TestCheckResourceAttr("tfcoremock_simple_resource.resource", "id", "slow_resource"),
+			},
+		},
+	})
+}
+
 func TestAccSimpleResourceDefers(t *testing.T) {
 	t.Cleanup(CleanupTestingDirectories(t))
 	resource.Test(t, resource.TestCase{
@@ -486,3 +1003,122 @@ func TestAccSimpleResourceDefers(t *testing.T) {
 		},
 	})
 }
+
+func TestAccSimpleResourceDefersOnPattern(t *testing.T) {
+	t.Cleanup(CleanupTestingDirectories(t))
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: ProviderFactories(""),
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipIfNotAlpha(), // deferrals only supported in alpha
+		},
+		AdditionalCLIOptions: &resource.AdditionalCLIOptions{
+			Apply: resource.ApplyOptions{
+				AllowDeferral: true,
+			},
+			Plan: resource.PlanOptions{
+				AllowDeferral: true,
+			},
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: LoadFile(t, "testdata/deferral_pattern/main.tf"),
+				Check: func(state *terraform.State) error {
+					if len(state.Modules[0].Resources) > 0 {
+						return errors.New("expected no resources to be created")
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestAccSimpleDataSourceDefers(t *testing.T) {
+	t.Cleanup(CleanupTestingDirectories(t))
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: ProviderFactories(""),
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipIfNotAlpha(), // deferrals only supported in alpha
+		},
+		AdditionalCLIOptions: &resource.AdditionalCLIOptions{
+			Apply: resource.ApplyOptions{
+				AllowDeferral: true,
+			},
+			Plan: resource.PlanOptions{
+				AllowDeferral: true,
+			},
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: LoadFile(t, "testdata/deferral_data_source/main.tf"),
+				Check: func(state *terraform.State) error {
+					if len(state.Modules[0].Resources) > 0 {
+						return errors.New("expected no resources to be created")
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestAccSimpleResourceDefersWhenUnknown(t *testing.T) {
+	t.Cleanup(CleanupTestingDirectories(t))
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: ProviderFactories(""),
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipIfNotAlpha(), // deferrals only supported in alpha
+		},
+		AdditionalCLIOptions: &resource.AdditionalCLIOptions{
+			Apply: resource.ApplyOptions{
+				AllowDeferral: true,
+			},
+			Plan: resource.PlanOptions{
+				AllowDeferral: true,
+			},
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: LoadFile(t, "testdata/deferral_when_unknown/main.tf"),
+				Check: func(state *terraform.State) error {
+					// Only the resource with an unknown ID should be deferred.
+					if len(state.Modules[0].Resources) != 1 {
+						return errors.New("expected exactly one resource to be created")
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestAccSimpleResourceDefersOnUnknownProviderConfig(t *testing.T) {
+	t.Cleanup(CleanupTestingDirectories(t))
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: ProviderFactories(""),
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipIfNotAlpha(), // deferrals only supported in alpha
+		},
+		AdditionalCLIOptions: &resource.AdditionalCLIOptions{
+			Apply: resource.ApplyOptions{
+				AllowDeferral: true,
+			},
+			Plan: resource.PlanOptions{
+				AllowDeferral: true,
+			},
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: LoadFile(t, "testdata/deferral_provider_config/main.tf"),
+				Check: func(state *terraform.State) error {
+					// Only the resource using the deferred provider should be
+					// deferred.
+					if len(state.Modules[0].Resources) != 1 {
+						return errors.New("expected exactly one resource to be created")
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
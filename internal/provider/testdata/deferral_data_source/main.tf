@@ -0,0 +1,15 @@
+provider "tfcoremock" {
+  deferral {
+    operations = ["read_data_source"]
+    id         = "simple_resource"
+    reason     = "absent_prereq"
+  }
+}
+
+data "tfcoremock_simple_resource" "data" {
+  id = "simple_resource"
+}
+
+resource "tfcoremock_simple_resource" "resource" {
+  integer = data.tfcoremock_simple_resource.data.integer
+}
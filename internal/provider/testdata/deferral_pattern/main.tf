@@ -0,0 +1,10 @@
+provider "tfcoremock" {
+  deferral {
+    id    = "^defer_[a-z]+$"
+    match = "regex"
+  }
+}
+
+resource "tfcoremock_simple_resource" "resource" {
+  id = "defer_me"
+}
@@ -0,0 +1,12 @@
+provider "tfcoremock" {
+  alias = "deferred"
+
+  defer_on_unknown_config = true
+  fail_on_create          = [tfcoremock_simple_resource.main.id]
+}
+
+resource "tfcoremock_simple_resource" "main" {}
+
+resource "tfcoremock_simple_resource" "other" {
+  provider = tfcoremock.deferred
+}
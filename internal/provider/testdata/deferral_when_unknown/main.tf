@@ -0,0 +1,11 @@
+provider "tfcoremock" {
+  deferral {
+    unknown_attributes = ["id"]
+  }
+}
+
+resource "tfcoremock_simple_resource" "main" {}
+
+resource "tfcoremock_simple_resource" "other" {
+  id = tfcoremock_simple_resource.main.id
+}
@@ -0,0 +1,11 @@
+provider "tfcoremock" {
+  delay {
+    operations = ["create", "read", "delete"]
+    id         = "slow_*"
+    duration   = "100ms"
+  }
+}
+
+resource "tfcoremock_simple_resource" "resource" {
+  id = "slow_resource"
+}
@@ -0,0 +1,11 @@
+provider "tfcoremock" {
+  drift {
+    id     = "removed"
+    remove = true
+  }
+}
+
+resource "tfcoremock_simple_resource" "test" {
+  id     = "removed"
+  string = "hello"
+}
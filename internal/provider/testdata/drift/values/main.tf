@@ -0,0 +1,13 @@
+provider "tfcoremock" {
+  drift {
+    id = "drifted"
+    values = {
+      string = jsonencode({ string = "drifted" })
+    }
+  }
+}
+
+resource "tfcoremock_simple_resource" "test" {
+  id     = "drifted"
+  string = "hello"
+}
@@ -0,0 +1,8 @@
+resource "tfcoremock_dynamic_resource" "resource" {
+  id    = "fail_resource"
+  value = "hello, world"
+}
+
+resource "tfcoremock_simple_resource" "resource" {
+  id = "fail_simple"
+}
@@ -0,0 +1,3 @@
+resource "tfcoremock_dynamic_resource" "test" {
+  value = "world"
+}
@@ -0,0 +1,7 @@
+resource "tfcoremock_dynamic_resource" "test" {
+  id   = "my-resource"
+  name = "hello"
+  network = {
+    zone = "example.com"
+  }
+}
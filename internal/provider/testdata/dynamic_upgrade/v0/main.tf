@@ -0,0 +1,6 @@
+resource "tfcoremock_dynamic_resource" "test" {
+  name   = "hello"
+  host   = "localhost"
+  port   = "8080"
+  legacy = true
+}
@@ -0,0 +1,9 @@
+resource "tfcoremock_dynamic_resource" "test" {
+  title = "hello"
+  zone  = "zone-a"
+
+  endpoint {
+    host = "localhost"
+    port = 8080
+  }
+}
@@ -0,0 +1,8 @@
+provider "tfcoremock" {}
+
+resource "tfcoremock_dynamic_resource" "test" {
+  id   = "my-validated-resource"
+  name = "Hello"
+  port = 8080
+  tags = ["one", "two"]
+}
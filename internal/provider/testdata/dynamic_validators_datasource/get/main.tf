@@ -0,0 +1,5 @@
+provider "tfcoremock" {}
+
+data "tfcoremock_dynamic_resource" "test" {
+  id = "simple_resource"
+}
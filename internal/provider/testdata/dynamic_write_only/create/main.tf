@@ -0,0 +1,7 @@
+provider "tfcoremock" {}
+
+resource "tfcoremock_dynamic_resource" "test" {
+  id       = "my-write-only-resource"
+  name     = "hello"
+  password = "secret"
+}
@@ -0,0 +1,11 @@
+provider "tfcoremock" {}
+
+ephemeral "tfcoremock_dynamic_resource" "token" {
+  id       = "my-ephemeral-resource"
+  renew_at = "1h"
+}
+
+resource "tfcoremock_dynamic_resource" "test" {
+  id       = "my-dynamic-resource"
+  password = ephemeral.tfcoremock_dynamic_resource.token.token
+}
@@ -0,0 +1,6 @@
+provider "tfcoremock" {}
+
+ephemeral "tfcoremock_simple_resource" "test" {
+  id     = "my-ephemeral-resource"
+  string = "hello"
+}
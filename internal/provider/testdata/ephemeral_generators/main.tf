@@ -0,0 +1,9 @@
+provider "tfcoremock" {}
+
+ephemeral "tfcoremock_dynamic_resource" "version" {
+  id = "my-ephemeral-resource"
+}
+
+resource "tfcoremock_dynamic_resource" "test" {
+  id = "my-dynamic-resource"
+}
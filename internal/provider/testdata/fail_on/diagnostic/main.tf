@@ -0,0 +1,14 @@
+provider "tfcoremock" {
+  failure {
+    operations = ["create"]
+    id         = "iden"
+    summary    = "quota exceeded"
+    detail     = "the string attribute is too expensive"
+    attribute  = "string"
+  }
+}
+
+resource "tfcoremock_simple_resource" "resource" {
+  id     = "iden"
+  string = "hello"
+}
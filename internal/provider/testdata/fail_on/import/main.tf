@@ -0,0 +1,12 @@
+provider "tfcoremock" {
+  fail_on_import = ["iden"]
+}
+
+import {
+  to = tfcoremock_simple_resource.resource
+  id = "iden"
+}
+
+resource "tfcoremock_simple_resource" "resource" {
+  id = "iden"
+}
@@ -0,0 +1,14 @@
+provider "tfcoremock" {
+  fail_on_invoke = ["tfcoremock_simple_resource"]
+}
+
+resource "tfcoremock_simple_resource" "resource" {
+  lifecycle {
+    action_trigger {
+      events  = [before_create, before_update]
+      actions = [action.tfcoremock_simple_resource.action]
+    }
+  }
+}
+
+action "tfcoremock_simple_resource" "action" {}
@@ -0,0 +1,16 @@
+provider "tfcoremock" {
+  failure {
+    operations    = ["list"]
+    resource_type = "tfcoremock_simple_resource"
+    after_results = 1
+    summary       = "lost connection"
+  }
+}
+
+resource "tfcoremock_simple_resource" "one" {
+  id = "one"
+}
+
+resource "tfcoremock_simple_resource" "two" {
+  id = "two"
+}
@@ -0,0 +1,4 @@
+
+list "tfcoremock_simple_resource" "resource" {
+  provider = tfcoremock
+}
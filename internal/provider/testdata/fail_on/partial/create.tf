@@ -0,0 +1,11 @@
+provider "tfcoremock" {
+  failure {
+    operations = ["create"]
+    id         = "iden"
+    partial    = true
+  }
+}
+
+resource "tfcoremock_simple_resource" "resource" {
+  id = "iden"
+}
@@ -0,0 +1,12 @@
+provider "tfcoremock" {
+  failure {
+    operations    = ["create"]
+    resource_type = "tfcoremock_*_resource"
+    id            = "db-*"
+  }
+}
+
+resource "tfcoremock_simple_resource" "resource" {
+  count = 2
+  id    = "db-${count.index}"
+}
@@ -0,0 +1,7 @@
+provider "tfcoremock" {
+  failure {
+    operations = ["plan_delete"]
+    id         = "iden"
+    summary    = "cannot plan destroy"
+  }
+}
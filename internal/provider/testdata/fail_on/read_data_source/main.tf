@@ -0,0 +1,7 @@
+provider "tfcoremock" {
+  fail_on_read_data_source = ["simple_resource"]
+}
+
+data "tfcoremock_simple_resource" "data" {
+  id = "simple_resource"
+}
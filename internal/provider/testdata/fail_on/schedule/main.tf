@@ -0,0 +1,11 @@
+provider "tfcoremock" {
+  failure {
+    operations  = ["create"]
+    id          = "iden"
+    first_calls = 1
+  }
+}
+
+resource "tfcoremock_simple_resource" "resource" {
+  id = "iden"
+}
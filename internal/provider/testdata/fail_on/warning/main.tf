@@ -0,0 +1,12 @@
+provider "tfcoremock" {
+  failure {
+    operations = ["create", "read", "update", "delete"]
+    id         = "iden"
+    severity   = "warning"
+    summary    = "resource is degraded"
+  }
+}
+
+resource "tfcoremock_simple_resource" "resource" {
+  id = "iden"
+}
@@ -0,0 +1,3 @@
+resource "tfcoremock_simple_resource" "resource" {
+  id = "iden"
+}
@@ -0,0 +1,26 @@
+terraform {
+  required_providers {
+    tfcoremock = {
+      source = "hashicorp/tfcoremock"
+    }
+  }
+}
+
+provider "tfcoremock" {}
+
+resource "tfcoremock_simple_resource" "test" {
+  id     = "my-function-resource"
+  string = provider::tfcoremock::region_name("us-east-1")
+}
+
+output "default" {
+  value = provider::tfcoremock::region_name("eu-west-2")
+}
+
+output "total" {
+  value = provider::tfcoremock::total(1, 2)
+}
+
+output "identity" {
+  value = join(",", provider::tfcoremock::identity(["one", "two"]))
+}
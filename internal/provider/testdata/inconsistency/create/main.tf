@@ -0,0 +1,4 @@
+resource "tfcoremock_simple_resource" "test" {
+  id     = "inconsistent"
+  string = "hello"
+}
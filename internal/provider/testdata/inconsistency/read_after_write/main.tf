@@ -0,0 +1,13 @@
+provider "tfcoremock" {
+  inconsistency {
+    id               = "inconsistent"
+    operations       = ["update"]
+    reads            = 1
+    read_after_write = true
+  }
+}
+
+resource "tfcoremock_simple_resource" "test" {
+  id     = "inconsistent"
+  string = "world"
+}
@@ -0,0 +1,12 @@
+provider "tfcoremock" {
+  inconsistency {
+    id         = "inconsistent"
+    operations = ["update"]
+    reads      = 1
+  }
+}
+
+resource "tfcoremock_simple_resource" "test" {
+  id     = "inconsistent"
+  string = "world"
+}
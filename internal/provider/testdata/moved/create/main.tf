@@ -0,0 +1,7 @@
+provider "tfcoremock" {}
+
+resource "tfcoremock_simple_resource" "test" {
+  id      = "my-moved-resource"
+  string  = "hello"
+  integer = 42
+}
@@ -0,0 +1,12 @@
+provider "tfcoremock" {}
+
+moved {
+  from = tfcoremock_simple_resource.test
+  to   = tfcoremock_dynamic_resource.test
+}
+
+resource "tfcoremock_dynamic_resource" "test" {
+  id    = "my-moved-resource"
+  name  = "hello"
+  total = 42
+}
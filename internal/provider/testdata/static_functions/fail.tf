@@ -0,0 +1,13 @@
+terraform {
+  required_providers {
+    tfcoremock = {
+      source = "hashicorp/tfcoremock"
+    }
+  }
+}
+
+provider "tfcoremock" {}
+
+output "fail" {
+  value = provider::tfcoremock::fail(2, "forced failure", "blamed")
+}
@@ -0,0 +1,31 @@
+terraform {
+  required_providers {
+    tfcoremock = {
+      source = "hashicorp/tfcoremock"
+    }
+  }
+}
+
+provider "tfcoremock" {}
+
+resource "tfcoremock_simple_resource" "test" {
+  id     = "my-function-resource"
+  string = provider::tfcoremock::echo("hello")
+}
+
+output "echo" {
+  value = provider::tfcoremock::echo({ name = "hello" }).name
+}
+
+output "sensitive_echo" {
+  value     = provider::tfcoremock::sensitive_echo(sensitive("secret"))
+  sensitive = true
+}
+
+output "unknown_passthrough" {
+  value = provider::tfcoremock::unknown_passthrough(tfcoremock_simple_resource.test.id)
+}
+
+output "variadic_count" {
+  value = provider::tfcoremock::variadic_count("one", 2, null)
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/action"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 
+	"github.com/hashicorp/terraform-provider-tfcoremock/internal/behaviour"
 	"github.com/hashicorp/terraform-provider-tfcoremock/internal/data"
 	"github.com/hashicorp/terraform-provider-tfcoremock/internal/schema"
 )
@@ -20,6 +21,10 @@ var _ action.Action = Action{}
 type Action struct {
 	Name           string
 	InternalSchema schema.Schema
+
+	Behaviours behaviour.Behaviours
+	Faults     behaviour.Reader
+	Counter    behaviour.Counter
 }
 
 func (a Action) Metadata(ctx context.Context, request action.MetadataRequest, response *action.MetadataResponse) {
@@ -40,6 +45,26 @@ func (a Action) Invoke(ctx context.Context, request action.InvokeRequest, respon
 		return
 	}
 
+	// Actions don't have an id, so failures can only target them by type.
+	behaviours, diags := loadBehaviours(a.Behaviours, a.Faults)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	failure, diags := evaluateBehaviours(ctx, behaviours, a.Counter, behaviour.Invoke, a.Name, "")
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	if failure != nil {
+		response.Diagnostics.Append(failure.ToDiagnostic("failed to invoke action", "forced failure"))
+		if response.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	msg, err := json.Marshal(resource)
 	if err != nil {
 		response.Diagnostics.AddError("failed to marshal action data", err.Error())
@@ -0,0 +1,192 @@
+// Copyright IBM Corp. 2022, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/hashicorp/terraform-provider-tfcoremock/internal/behaviour"
+	"github.com/hashicorp/terraform-provider-tfcoremock/internal/client"
+	"github.com/hashicorp/terraform-provider-tfcoremock/internal/data"
+)
+
+// loadBehaviours returns the behaviours from the provider configuration,
+// preceded by any behaviours in the faults file.
+//
+// The faults file is read again for every operation, so its behaviours can
+// change between operations without changing the provider configuration.
+func loadBehaviours(behaviours behaviour.Behaviours, faults behaviour.Reader) (behaviour.Behaviours, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if faults == nil {
+		return behaviours, diags
+	}
+
+	fromFile, err := faults.Read()
+	if err != nil {
+		diags.AddError("failed to read faults file", err.Error())
+		return behaviours, diags
+	}
+	return fromFile.Merge(behaviours), diags
+}
+
+// evaluateBehaviours waits out any delay that applies to the given operation
+// on the resource with the given type and id, crashes the provider if it has
+// been told to, and then returns the failure that should be applied to the
+// operation, if any.
+//
+// If the context is cancelled during the delay then an error diagnostic is
+// returned immediately.
+func evaluateBehaviours(ctx context.Context, behaviours behaviour.Behaviours, counter behaviour.Counter, operation behaviour.Operation, typeName string, id string) (*behaviour.Failure, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	effects, err := behaviours.Evaluate(operation, typeName, id, counter)
+	if err != nil {
+		diags.AddError("failed to evaluate behaviours", err.Error())
+		return nil, diags
+	}
+
+	if effects.Delay != nil {
+		if err := effects.Delay.Wait(ctx); err != nil {
+			diags.AddError("operation cancelled", fmt.Sprintf("the %s operation was cancelled while it was being delayed: %s", operation, err))
+			return nil, diags
+		}
+	}
+
+	if effects.Crash != nil {
+		effects.Crash.Trigger(operation, typeName, id)
+	}
+	return effects.Failure, diags
+}
+
+// evaluateDeferral returns the deferral that applies to the given operation on
+// the resource with the given type and id, if any. The config should be null
+// for operations that aren't given a configuration.
+//
+// An error diagnostic is returned instead if the operation should be deferred
+// but the current version of Terraform does not support deferrals.
+func evaluateDeferral(behaviours behaviour.Behaviours, operation behaviour.Operation, typeName string, id string, config tftypes.Value, deferralAllowed bool) (*behaviour.Deferral, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	deferral := behaviours.Deferral(operation, typeName, id, unknownAttributes(config))
+	if deferral == nil {
+		return nil, diags
+	}
+
+	if !deferralAllowed {
+		if deferral.WhenUnknown || len(deferral.UnknownAttributes) > 0 {
+			diags.AddError("Invalid resource deferral", "The configuration contains unknown values that were marked as \"should be deferred\", but the current version of Terraform does not support deferrals.")
+			return nil, diags
+		}
+		diags.AddAttributeError(path.Root("id"), "Invalid resource deferral", "This `id` was marked as \"should be deferred\", but the current version of Terraform does not support deferrals.")
+		return nil, diags
+	}
+	return deferral, diags
+}
+
+// evaluateDrift returns the drift that applies to the current refresh of the
+// resource with the given type and id, if any.
+func evaluateDrift(behaviours behaviour.Behaviours, counter behaviour.Counter, typeName string, id string) (*behaviour.Drift, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	drift, err := behaviours.Drift(typeName, id, counter)
+	if err != nil {
+		diags.AddError("failed to evaluate drift", err.Error())
+		return nil, diags
+	}
+	return drift, diags
+}
+
+// recordInconsistency records the revision that reads of the resource with the
+// given type and id should return after it has been written, if any
+// inconsistency applies to the write. Previous is the version of the resource
+// before the write, and is nil for creates.
+func recordInconsistency(behaviours behaviour.Behaviours, revisions client.Revisions, operation behaviour.Operation, typeName string, id string, previous *data.Resource) (*behaviour.Inconsistency, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	inconsistency := behaviours.Inconsistency(operation, typeName, id)
+	if inconsistency == nil || revisions == nil {
+		return nil, diags
+	}
+
+	duration, err := inconsistency.GetDuration()
+	if err != nil {
+		diags.AddError("failed to evaluate inconsistency", err.Error())
+		return nil, diags
+	}
+
+	revision := client.Revision{
+		Reads: inconsistency.Reads,
+	}
+	if inconsistency.GetMode() == behaviour.InconsistencyStale {
+		revision.Previous = previous
+	}
+	if duration > 0 {
+		until := time.Now().Add(duration)
+		revision.Until = &until
+	}
+
+	if err := revisions.Record(id, revision); err != nil {
+		diags.AddError("failed to record inconsistency", err.Error())
+		return nil, diags
+	}
+	return inconsistency, diags
+}
+
+// unknownAttributes returns the names of the top-level attributes and blocks
+// in the configuration that are, or contain, unknown values.
+func unknownAttributes(config tftypes.Value) []string {
+	if config.IsNull() || !config.IsKnown() {
+		return nil
+	}
+
+	var values map[string]tftypes.Value
+	if err := config.As(&values); err != nil {
+		return nil
+	}
+
+	var unknown []string
+	for _, name := range slices.Sorted(maps.Keys(values)) {
+		if !values[name].IsFullyKnown() {
+			unknown = append(unknown, name)
+		}
+	}
+	return unknown
+}
+
+// resourceDeferred converts the reason for a deferral into the response
+// expected by the managed resource operations.
+func resourceDeferred(deferral *behaviour.Deferral) *resource.Deferred {
+	switch deferral.GetReason() {
+	case behaviour.DeferralProviderConfigUnknown:
+		return &resource.Deferred{Reason: resource.DeferredReasonProviderConfigUnknown}
+	case behaviour.DeferralAbsentPrereq:
+		return &resource.Deferred{Reason: resource.DeferredReasonAbsentPrereq}
+	default:
+		return &resource.Deferred{Reason: resource.DeferredReasonResourceConfigUnknown}
+	}
+}
+
+// dataSourceDeferred converts the reason for a deferral into the response
+// expected by data sources.
+func dataSourceDeferred(deferral *behaviour.Deferral) *datasource.Deferred {
+	switch deferral.GetReason() {
+	case behaviour.DeferralProviderConfigUnknown:
+		return &datasource.Deferred{Reason: datasource.DeferredReasonProviderConfigUnknown}
+	case behaviour.DeferralAbsentPrereq:
+		return &datasource.Deferred{Reason: datasource.DeferredReasonAbsentPrereq}
+	default:
+		return &datasource.Deferred{Reason: datasource.DeferredReasonDataSourceConfigUnknown}
+	}
+}
@@ -7,10 +7,13 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
 
+	"github.com/hashicorp/terraform-provider-tfcoremock/internal/behaviour"
 	"github.com/hashicorp/terraform-provider-tfcoremock/internal/client"
 	"github.com/hashicorp/terraform-provider-tfcoremock/internal/data"
 	"github.com/hashicorp/terraform-provider-tfcoremock/internal/schema"
@@ -22,6 +25,10 @@ type DataSource struct {
 	Name           string
 	InternalSchema schema.Schema
 	Client         client.Client
+
+	Behaviours behaviour.Behaviours
+	Faults     behaviour.Reader
+	Counter    behaviour.Counter
 }
 
 func (d DataSource) Metadata(ctx context.Context, request datasource.MetadataRequest, response *datasource.MetadataResponse) {
@@ -45,7 +52,46 @@ func (d DataSource) Read(ctx context.Context, request datasource.ReadRequest, re
 		return
 	}
 
-	data, err := d.Client.ReadDataSource(ctx, resource.GetId())
+	// If the id is unknown, then we leave it empty. This means behaviours
+	// that only target resource types will still apply.
+	var id string
+	if value, ok := resource.Values["id"]; ok {
+		id = *value.String
+	}
+
+	behaviours, diags := loadBehaviours(d.Behaviours, d.Faults)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	failure, diags := evaluateBehaviours(ctx, behaviours, d.Counter, behaviour.ReadDataSource, d.Name, id)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	if failure != nil {
+		response.Diagnostics.Append(failure.ToDiagnostic("failed to read data source", "forced failure"))
+		if response.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	deferral, diags := evaluateDeferral(behaviours, behaviour.ReadDataSource, d.Name, id, request.Config.Raw, request.ClientCapabilities.DeferralAllowed)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	if deferral != nil {
+		// The response already holds the configuration, so Terraform gets back
+		// exactly what it sent until the data source is read for real.
+		response.Deferred = dataSourceDeferred(deferral)
+		return
+	}
+
+	data, err := d.Client.ReadDataSource(ctx, id)
 	if err != nil {
 		response.Diagnostics.AddError("failed to read data source", err.Error())
 		return
@@ -54,9 +100,32 @@ func (d DataSource) Read(ctx context.Context, request datasource.ReadRequest, re
 	if data == nil {
 		response.Diagnostics.AddError(
 			"target data source does not exist",
-			fmt.Sprintf("data source at %s could not be found in data directory", resource.GetId()))
+			fmt.Sprintf("data source at %s could not be found in data directory", id))
 	}
 
 	typ := request.Config.Schema.Type().TerraformType(ctx)
 	response.Diagnostics.Append(response.State.Set(ctx, data.WithType(typ.(tftypes.Object)))...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	// Data sources have no configurable attributes for Terraform to validate,
+	// so the validators check the values read from the data directory instead.
+	response.Diagnostics.Append(validateState(ctx, d.InternalSchema, response.State)...)
+}
+
+func validateState(ctx context.Context, internal schema.Schema, state tfsdk.State) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, attribute := range internal.AttributeValidators() {
+		paths, pathDiags := state.PathMatches(ctx, attribute.Expression)
+		diags.Append(pathDiags...)
+		for _, path := range paths {
+			var value attr.Value
+			diags.Append(state.GetAttribute(ctx, path, &value)...)
+			for _, v := range attribute.Validators {
+				diags.Append(v.ValidateValue(ctx, path, value)...)
+			}
+		}
+	}
+	return diags
 }
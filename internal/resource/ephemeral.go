@@ -0,0 +1,189 @@
+// Copyright IBM Corp. 2022, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+
+	"github.com/hashicorp/terraform-provider-tfcoremock/internal/behaviour"
+	"github.com/hashicorp/terraform-provider-tfcoremock/internal/computed"
+	"github.com/hashicorp/terraform-provider-tfcoremock/internal/data"
+	"github.com/hashicorp/terraform-provider-tfcoremock/internal/schema"
+)
+
+var _ ephemeral.EphemeralResource = EphemeralResource{}
+var _ ephemeral.EphemeralResourceWithRenew = EphemeralResource{}
+var _ ephemeral.EphemeralResourceWithClose = EphemeralResource{}
+
+// ephemeralKey is the key in the private data of an ephemeral resource that
+// holds what Renew and Close need to know about it.
+const ephemeralKey = "ephemeral"
+
+// ephemeralPrivate is what Open records in the private data of an ephemeral
+// resource, as Renew and Close don't receive the configuration.
+type ephemeralPrivate struct {
+	ID      string `json:"id"`
+	RenewAt string `json:"renew_at,omitempty"`
+}
+
+type EphemeralResource struct {
+	Name           string
+	InternalSchema schema.Schema
+
+	Counter behaviour.Counter
+	Log     *EphemeralLog
+}
+
+func (e EphemeralResource) Metadata(ctx context.Context, request ephemeral.MetadataRequest, response *ephemeral.MetadataResponse) {
+	response.TypeName = e.Name
+}
+
+func (e EphemeralResource) Schema(ctx context.Context, request ephemeral.SchemaRequest, response *ephemeral.SchemaResponse) {
+	var err error
+	if response.Schema, err = e.InternalSchema.ToTerraformEphemeralResourceSchema(); err != nil {
+		response.Diagnostics.Append(diag.NewErrorDiagnostic(fmt.Sprintf("failed to build ephemeral resource schema for '%s'", e.Name), err.Error()))
+	}
+}
+
+func (e EphemeralResource) Open(ctx context.Context, request ephemeral.OpenRequest, response *ephemeral.OpenResponse) {
+	resource := &data.Resource{}
+	response.Diagnostics.Append(request.Config.Get(ctx, &resource)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	resource.ResourceType = e.Name
+
+	if _, ok := resource.Values["id"]; !ok {
+		id, err := uuid.GenerateUUID()
+		if err != nil {
+			response.Diagnostics.Append(diag.NewErrorDiagnostic("failed to generate id", err.Error()))
+			return
+		}
+		resource.Values["id"] = data.Value{
+			String: &id,
+		}
+	}
+
+	// Terraform opens ephemeral resources during every plan and apply, so
+	// their generators count separately to stop them advancing the sequences
+	// of the managed resource with the same type.
+	counter := behaviour.PrefixedCounter{Prefix: "ephemeral/", Counter: e.Counter}
+	if err := computed.GenerateComputedValues(resource, e.InternalSchema, counter); err != nil {
+		response.Diagnostics.Append(diag.NewErrorDiagnostic("failed to generate computed values", err.Error()))
+		return
+	}
+
+	private := ephemeralPrivate{
+		ID: resource.GetId(),
+	}
+	if value, ok := resource.Values[schema.RenewAt]; ok && e.InternalSchema.HasRenewAt() {
+		private.RenewAt = *value.String
+	}
+
+	renewAt, err := private.nextRenewal()
+	if err != nil {
+		response.Diagnostics.AddAttributeError(path.Root(schema.RenewAt), "invalid renew_at", err.Error())
+		return
+	}
+
+	response.Diagnostics.Append(response.Result.Set(ctx, resource)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	response.RenewAt = renewAt
+
+	jsonData, err := json.Marshal(private)
+	if err != nil {
+		response.Diagnostics.AddError("failed to record ephemeral resource", err.Error())
+		return
+	}
+	response.Diagnostics.Append(response.Private.SetKey(ctx, ephemeralKey, jsonData)...)
+
+	response.Diagnostics.Append(e.log("open", private.ID)...)
+}
+
+func (e EphemeralResource) Renew(ctx context.Context, request ephemeral.RenewRequest, response *ephemeral.RenewResponse) {
+	private, diags := e.private(ctx, request.Private.GetKey)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	renewAt, err := private.nextRenewal()
+	if err != nil {
+		response.Diagnostics.AddError("failed to renew ephemeral resource", err.Error())
+		return
+	}
+	response.RenewAt = renewAt
+
+	response.Diagnostics.Append(e.log("renew", private.ID)...)
+}
+
+func (e EphemeralResource) Close(ctx context.Context, request ephemeral.CloseRequest, response *ephemeral.CloseResponse) {
+	private, diags := e.private(ctx, request.Private.GetKey)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	response.Diagnostics.Append(e.log("close", private.ID)...)
+}
+
+// private reads the data Open recorded about the ephemeral resource.
+func (e EphemeralResource) private(ctx context.Context, getKey func(context.Context, string) ([]byte, diag.Diagnostics)) (ephemeralPrivate, diag.Diagnostics) {
+	var private ephemeralPrivate
+
+	jsonData, diags := getKey(ctx, ephemeralKey)
+	if diags.HasError() {
+		return private, diags
+	}
+	if len(jsonData) == 0 {
+		diags.AddError("missing ephemeral resource data", "the ephemeral resource has no private data, so it wasn't opened by this provider")
+		return private, diags
+	}
+
+	if err := json.Unmarshal(jsonData, &private); err != nil {
+		diags.AddError("failed to read ephemeral resource data", err.Error())
+	}
+	return private, diags
+}
+
+func (e EphemeralResource) log(operation string, id string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	err := e.Log.Append(EphemeralEvent{
+		Operation:    operation,
+		ResourceType: e.Name,
+		ID:           id,
+		Time:         time.Now().UTC(),
+	})
+	if err != nil {
+		diags.AddError("failed to log ephemeral resource call", err.Error())
+	}
+	return diags
+}
+
+// nextRenewal returns when Terraform should next renew the ephemeral resource,
+// or the zero time if it should never be renewed.
+func (private ephemeralPrivate) nextRenewal() (time.Time, error) {
+	if len(private.RenewAt) == 0 {
+		return time.Time{}, nil
+	}
+
+	duration, err := time.ParseDuration(private.RenewAt)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid duration '%s': %w", private.RenewAt, err)
+	}
+	if duration <= 0 {
+		return time.Time{}, fmt.Errorf("renew_at must be positive, not '%s'", private.RenewAt)
+	}
+	return time.Now().Add(duration), nil
+}
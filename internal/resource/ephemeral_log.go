@@ -0,0 +1,59 @@
+// Copyright IBM Corp. 2022, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// EphemeralEvent is a single call Terraform made to an ephemeral resource.
+type EphemeralEvent struct {
+	Operation    string    `json:"operation"`
+	ResourceType string    `json:"resource_type"`
+	ID           string    `json:"id"`
+	Time         time.Time `json:"time"`
+}
+
+// EphemeralLog appends every open, renew and close of an ephemeral resource to
+// a file, with one JSON object per line, so tests can check the sequence of
+// calls Terraform made.
+type EphemeralLog struct {
+	// File is the file events are appended to. If it is empty, events are
+	// discarded.
+	File string
+
+	mutex sync.Mutex
+}
+
+func (log *EphemeralLog) Append(event EphemeralEvent) error {
+	if log == nil || len(log.File) == 0 {
+		return nil
+	}
+
+	log.mutex.Lock()
+	defer log.mutex.Unlock()
+
+	jsonData, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(log.File), 0700); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(log.File, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(append(jsonData, '\n')); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
@@ -0,0 +1,129 @@
+// Copyright IBM Corp. 2022, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/hashicorp/terraform-provider-tfcoremock/internal/data"
+	"github.com/hashicorp/terraform-provider-tfcoremock/internal/schema"
+)
+
+var _ function.Function = Function{}
+
+type Function struct {
+	Name             string
+	InternalFunction schema.Function
+}
+
+func (f Function) Metadata(ctx context.Context, request function.MetadataRequest, response *function.MetadataResponse) {
+	response.Name = f.Name
+}
+
+func (f Function) Definition(ctx context.Context, request function.DefinitionRequest, response *function.DefinitionResponse) {
+	var err error
+	if response.Definition, err = f.InternalFunction.ToTerraformFunctionDefinition(); err != nil {
+		response.Diagnostics.AddError(fmt.Sprintf("failed to build function definition for '%s'", f.Name), err.Error())
+	}
+}
+
+func (f Function) Run(ctx context.Context, request function.RunRequest, response *function.RunResponse) {
+	returnType, err := f.InternalFunction.Return.ToTerraformType()
+	if err != nil {
+		response.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	count := len(f.InternalFunction.Parameters)
+	if f.InternalFunction.VariadicParameter != nil {
+		count++
+	}
+
+	arguments := make([]attr.Value, count)
+	targets := make([]any, count)
+	for ix := range arguments {
+		targets[ix] = &arguments[ix]
+	}
+	if count > 0 {
+		if response.Error = request.Arguments.Get(ctx, targets...); response.Error != nil {
+			return
+		}
+	}
+
+	// The variadic arguments arrive as a single tuple after the other
+	// arguments, so we flatten them into the same list.
+	var values []tftypes.Value
+	for ix, argument := range arguments {
+		value, err := argument.ToTerraformValue(ctx)
+		if err != nil {
+			response.Error = function.NewArgumentFuncError(int64(ix), err.Error())
+			return
+		}
+
+		if ix < len(f.InternalFunction.Parameters) {
+			values = append(values, value)
+			continue
+		}
+
+		var variadic []tftypes.Value
+		if err := value.As(&variadic); err != nil {
+			response.Error = function.NewArgumentFuncError(int64(ix), err.Error())
+			return
+		}
+		values = append(values, variadic...)
+	}
+
+	tfType := returnType.TerraformType(ctx)
+
+	var inputs []data.Value
+	for _, value := range values {
+		if !value.IsFullyKnown() {
+			// We can't decide on a result until we know all the arguments.
+			result, err := returnType.ValueFromTerraform(ctx, tftypes.NewValue(tfType, tftypes.UnknownValue))
+			if err != nil {
+				response.Error = function.NewFuncError(err.Error())
+				return
+			}
+			response.Error = response.Result.Set(ctx, result)
+			return
+		}
+
+		if value.IsNull() {
+			// An empty value converts back into null for every type.
+			inputs = append(inputs, data.Value{})
+			continue
+		}
+
+		input, err := data.FromTerraform5Value(value)
+		if err != nil {
+			response.Error = function.NewFuncError(err.Error())
+			return
+		}
+		inputs = append(inputs, input)
+	}
+
+	output, err := f.InternalFunction.Evaluate(inputs)
+	if err != nil {
+		response.Error = function.NewFuncError(fmt.Sprintf("%s: %s", f.Name, err.Error()))
+		return
+	}
+
+	value, err := data.ToTerraform5Value(output, tfType)
+	if err != nil {
+		response.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	result, err := returnType.ValueFromTerraform(ctx, value)
+	if err != nil {
+		response.Error = function.NewFuncError(err.Error())
+		return
+	}
+	response.Error = response.Result.Set(ctx, result)
+}
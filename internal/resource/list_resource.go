@@ -12,6 +12,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
 
+	"github.com/hashicorp/terraform-provider-tfcoremock/internal/behaviour"
 	"github.com/hashicorp/terraform-provider-tfcoremock/internal/client"
 	"github.com/hashicorp/terraform-provider-tfcoremock/internal/data"
 	"github.com/hashicorp/terraform-provider-tfcoremock/internal/schema"
@@ -23,6 +24,14 @@ type ListResource struct {
 	Name           string
 	InternalSchema schema.Schema
 	Client         client.Client
+
+	Behaviours behaviour.Behaviours
+	Faults     behaviour.Reader
+	Counter    behaviour.Counter
+
+	// Deferred is true if the provider deferred its configuration. List
+	// resources can't be deferred, so they return no results instead.
+	Deferred bool
 }
 
 func (l ListResource) Metadata(ctx context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
@@ -40,6 +49,13 @@ func (l ListResource) ListResourceConfigSchema(ctx context.Context, request list
 }
 
 func (l ListResource) List(ctx context.Context, request list.ListRequest, stream *list.ListResultsStream) {
+	if l.Deferred {
+		stream.Results = list.ListResultsStreamDiagnostics(diag.Diagnostics{
+			diag.NewWarningDiagnostic("provider configuration deferred", "The provider configuration contains unknown values, so no resources were queried."),
+		})
+		return
+	}
+
 	resource := &data.Resource{
 		ResourceType: l.Name,
 	}
@@ -50,13 +66,53 @@ func (l ListResource) List(ctx context.Context, request list.ListRequest, stream
 		return
 	}
 
+	var id *string
+	if value, ok := resource.Values["id"]; ok {
+		id = value.String
+	}
+
+	var filter string
+	if id != nil {
+		filter = *id
+	}
+
+	behaviours, diags := loadBehaviours(l.Behaviours, l.Faults)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	failure, diags := evaluateBehaviours(ctx, behaviours, l.Counter, behaviour.List, l.Name, filter)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
 	stream.Results = func(yield func(list.ListResult) bool) {
-		var id *string
-		if value, ok := resource.Values["id"]; ok {
-			id = value.String
+		// stopped is set once we shouldn't yield any more results, either
+		// because Terraform told us to stop or because we injected an error.
+		stopped := false
+
+		// pending is true while there is a failure that has still to be
+		// injected into the stream.
+		pending := failure != nil
+
+		inject := func() {
+			pending = false
+			diags := diag.Diagnostics{failure.ToDiagnostic("failed to query resources", "forced failure")}
+			stopped = !yield(list.ListResult{Diagnostics: diags}) || diags.HasError()
+		}
+
+		if pending && failure.AfterResults == 0 {
+			inject()
 		}
 
+		var count int64
 		err := l.Client.ListResources(ctx, client.Filter(l.Name), id, func(resource *data.Resource, err error) {
+			if stopped {
+				return
+			}
+
 			result := request.NewListResult(ctx)
 			if err != nil {
 				result.Diagnostics.Append(diag.NewErrorDiagnostic("failed to query resource", err.Error()))
@@ -70,14 +126,25 @@ func (l ListResource) List(ctx context.Context, request list.ListRequest, stream
 					result.Diagnostics.Append(result.Resource.Set(ctx, resource.WithType(typ.(tftypes.Object)))...)
 				}
 			}
-			yield(result)
+			stopped = !yield(result)
+
+			count++
+			if !stopped && pending && count == failure.AfterResults {
+				inject()
+			}
 		}, request.Limit)
-		if err != nil {
-			yield(list.ListResult{
+		if err != nil && !stopped {
+			stopped = !yield(list.ListResult{
 				Diagnostics: diag.Diagnostics{
 					diag.NewErrorDiagnostic("failed to query resources", err.Error()),
 				},
 			})
 		}
+
+		if !stopped && pending {
+			// Then there were fewer results than the failure was waiting for,
+			// so we'll raise it at the end instead.
+			inject()
+		}
 	}
 }
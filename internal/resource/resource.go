@@ -4,10 +4,16 @@
 package resource
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"math/big"
 	"os"
-	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/hashicorp/go-uuid"
 	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
@@ -15,10 +21,14 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
 
 	"github.com/hashicorp/terraform-provider-tfcoremock/internal/computed"
 
+	"github.com/hashicorp/terraform-provider-tfcoremock/internal/behaviour"
 	"github.com/hashicorp/terraform-provider-tfcoremock/internal/client"
 	"github.com/hashicorp/terraform-provider-tfcoremock/internal/data"
 	"github.com/hashicorp/terraform-provider-tfcoremock/internal/schema"
@@ -28,17 +38,22 @@ var _ resource.Resource = Resource{}
 var _ resource.ResourceWithIdentity = Resource{}
 var _ resource.ResourceWithImportState = Resource{}
 var _ resource.ResourceWithModifyPlan = Resource{}
+var _ resource.ResourceWithUpgradeState = Resource{}
+var _ resource.ResourceWithMoveState = Resource{}
+
+// writeOnlyKey is the key in the private state holding the hashes of any
+// write-only values.
+const writeOnlyKey = "write_only_hashes"
 
 type Resource struct {
 	Name           string
 	InternalSchema schema.Schema
 	Client         client.Client
 
-	FailOnDelete []string
-	FailOnCreate []string
-	FailOnRead   []string
-	FailOnUpdate []string
-	DeferChanges []string
+	Behaviours behaviour.Behaviours
+	Faults     behaviour.Reader
+	Counter    behaviour.Counter
+	Revisions  client.Revisions
 }
 
 func (r Resource) Metadata(ctx context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
@@ -64,6 +79,15 @@ func (r Resource) IdentitySchema(ctx context.Context, request resource.IdentityS
 }
 
 func (r Resource) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
+	// Terraform never plans values for write-only attributes, but we make
+	// sure they can't reach the resource directory or the state.
+	planned, err := r.removeWriteOnly(request.Plan.Raw)
+	if err != nil {
+		response.Diagnostics.AddError("failed to remove write-only values", err.Error())
+		return
+	}
+	request.Plan.Raw = planned
+
 	resource := &data.Resource{}
 	response.Diagnostics.Append(request.Plan.Get(ctx, &resource)...)
 	if response.Diagnostics.HasError() {
@@ -84,23 +108,60 @@ func (r Resource) Create(ctx context.Context, request resource.CreateRequest, re
 	}
 
 	// Now go and do the rest of the computed values.
-	if err := computed.GenerateComputedValues(resource, r.InternalSchema); err != nil {
+	if err := computed.GenerateComputedValues(resource, r.InternalSchema, r.Counter); err != nil {
 		response.Diagnostics.Append(diag.NewErrorDiagnostic("failed to generate computed values", err.Error()))
 		return
 	}
 
-	if slices.Contains(r.FailOnCreate, resource.GetId()) {
-		response.Diagnostics.Append(diag.NewErrorDiagnostic("failed to create resource", "forced failure"))
+	behaviours, diags := loadBehaviours(r.Behaviours, r.Faults)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
 		return
 	}
 
+	failure, diags := evaluateBehaviours(ctx, behaviours, r.Counter, behaviour.Create, r.Name, resource.GetId())
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	if failure != nil && !failure.Partial {
+		response.Diagnostics.Append(failure.ToDiagnostic("failed to create resource", "forced failure"))
+		if response.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	if err := r.Client.WriteResource(ctx, resource); err != nil {
 		response.Diagnostics.Append(diag.NewErrorDiagnostic("failed to write resource", err.Error()))
 		return
 	}
 
-	response.Diagnostics.Append(response.State.Set(ctx, resource)...)
+	inconsistency, diags := recordInconsistency(behaviours, r.Revisions, behaviour.Create, r.Name, resource.GetId(), nil)
+	response.Diagnostics.Append(diags...)
+
+	if inconsistency != nil && inconsistency.ReadAfterWrite {
+		typ := request.Plan.Schema.Type().TerraformType(ctx)
+		if !r.readAfterWrite(ctx, resource, typ.(tftypes.Object), &response.State, &response.Diagnostics) {
+			return
+		}
+	} else {
+		response.Diagnostics.Append(response.State.Set(ctx, resource)...)
+	}
 	response.Diagnostics.Append(response.Identity.Set(ctx, resource.Identity())...)
+
+	hashes, err := r.hashWriteOnly(request.Config.Raw)
+	if err != nil {
+		response.Diagnostics.AddError("failed to hash write-only values", err.Error())
+		return
+	}
+	response.Diagnostics.Append(response.Private.SetKey(ctx, writeOnlyKey, hashes)...)
+
+	if failure != nil && failure.Partial {
+		// Partial failures happen after the resource has been written, so
+		// Terraform still receives and records the new state.
+		response.Diagnostics.Append(failure.ToDiagnostic("failed to create resource", "forced failure"))
+	}
 }
 
 func (r Resource) Read(ctx context.Context, request resource.ReadRequest, response *resource.ReadResponse) {
@@ -110,8 +171,36 @@ func (r Resource) Read(ctx context.Context, request resource.ReadRequest, respon
 		return
 	}
 
-	if slices.Contains(r.FailOnRead, resource.GetId()) {
-		response.Diagnostics.Append(diag.NewErrorDiagnostic("failed to read resource", "forced failure"))
+	behaviours, diags := loadBehaviours(r.Behaviours, r.Faults)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	failure, diags := evaluateBehaviours(ctx, behaviours, r.Counter, behaviour.Read, r.Name, resource.GetId())
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	if failure != nil {
+		response.Diagnostics.Append(failure.ToDiagnostic("failed to read resource", "forced failure"))
+		if response.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	deferral, diags := evaluateDeferral(behaviours, behaviour.Read, r.Name, resource.GetId(), tftypes.Value{}, request.ClientCapabilities.DeferralAllowed)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	if deferral != nil {
+		// The response already holds the current state, so we just leave it
+		// unchanged for Terraform to refresh later.
+		response.Deferred = resourceDeferred(deferral)
+		response.Diagnostics.Append(response.Identity.Set(ctx, resource.Identity())...)
 		return
 	}
 
@@ -135,12 +224,46 @@ func (r Resource) Read(ctx context.Context, request resource.ReadRequest, respon
 		data = resource
 	}
 
+	drift, diags := evaluateDrift(behaviours, r.Counter, r.Name, resource.GetId())
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	if drift != nil {
+		if !drift.Apply(data) {
+			// Then the resource has been removed outside of Terraform, so we
+			// remove it from the client too and the drift sticks.
+			if err := r.Client.DeleteResource(ctx, resource.GetId()); err != nil {
+				response.Diagnostics.AddError("failed to remove drifted resource", err.Error())
+				return
+			}
+			response.State.RemoveResource(ctx)
+			response.Diagnostics.Append(response.Identity.Set(ctx, resource.Identity())...)
+			return
+		}
+
+		if err := r.Client.UpdateResource(ctx, data); err != nil {
+			response.Diagnostics.AddError("failed to update drifted resource", err.Error())
+			return
+		}
+	}
+
 	typ := request.State.Schema.Type().TerraformType(ctx)
 	response.Diagnostics.Append(response.State.Set(ctx, data.WithType(typ.(tftypes.Object)))...)
 	response.Diagnostics.Append(response.Identity.Set(ctx, data.Identity())...)
 }
 
 func (r Resource) Update(ctx context.Context, request resource.UpdateRequest, response *resource.UpdateResponse) {
+	// Terraform never plans values for write-only attributes, but we make
+	// sure they can't reach the resource directory or the state.
+	planned, err := r.removeWriteOnly(request.Plan.Raw)
+	if err != nil {
+		response.Diagnostics.AddError("failed to remove write-only values", err.Error())
+		return
+	}
+	request.Plan.Raw = planned
+
 	resource := &data.Resource{}
 	response.Diagnostics.Append(request.Plan.Get(ctx, &resource)...)
 	if response.Diagnostics.HasError() {
@@ -148,23 +271,67 @@ func (r Resource) Update(ctx context.Context, request resource.UpdateRequest, re
 	}
 	resource.ResourceType = r.Name
 
-	if err := computed.GenerateComputedValues(resource, r.InternalSchema); err != nil {
+	if err := computed.GenerateComputedValues(resource, r.InternalSchema, r.Counter); err != nil {
 		response.Diagnostics.Append(diag.NewErrorDiagnostic("failed to generate computed values", err.Error()))
 		return
 	}
 
-	if slices.Contains(r.FailOnUpdate, resource.GetId()) {
-		response.Diagnostics.Append(diag.NewErrorDiagnostic("failed to update resource", "forced failure"))
+	behaviours, diags := loadBehaviours(r.Behaviours, r.Faults)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
 		return
 	}
 
+	failure, diags := evaluateBehaviours(ctx, behaviours, r.Counter, behaviour.Update, r.Name, resource.GetId())
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	if failure != nil && !failure.Partial {
+		response.Diagnostics.Append(failure.ToDiagnostic("failed to update resource", "forced failure"))
+		if response.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	if err := r.Client.UpdateResource(ctx, resource); err != nil {
 		response.Diagnostics.AddError("failed to update resource", err.Error())
 		return
 	}
 
-	response.Diagnostics.Append(response.State.Set(ctx, resource)...)
+	previous := &data.Resource{}
+	response.Diagnostics.Append(request.State.Get(ctx, &previous)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	previous.ResourceType = r.Name
+
+	inconsistency, diags := recordInconsistency(behaviours, r.Revisions, behaviour.Update, r.Name, resource.GetId(), previous)
+	response.Diagnostics.Append(diags...)
+
+	if inconsistency != nil && inconsistency.ReadAfterWrite {
+		typ := request.Plan.Schema.Type().TerraformType(ctx)
+		if !r.readAfterWrite(ctx, resource, typ.(tftypes.Object), &response.State, &response.Diagnostics) {
+			return
+		}
+	} else {
+		response.Diagnostics.Append(response.State.Set(ctx, resource)...)
+	}
 	response.Diagnostics.Append(response.Identity.Set(ctx, resource.Identity())...)
+
+	hashes, err := r.hashWriteOnly(request.Config.Raw)
+	if err != nil {
+		response.Diagnostics.AddError("failed to hash write-only values", err.Error())
+		return
+	}
+	response.Diagnostics.Append(response.Private.SetKey(ctx, writeOnlyKey, hashes)...)
+
+	if failure != nil && failure.Partial {
+		// Partial failures happen after the resource has been written, so
+		// Terraform still receives and records the new state.
+		response.Diagnostics.Append(failure.ToDiagnostic("failed to update resource", "forced failure"))
+	}
 }
 
 func (r Resource) Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse) {
@@ -174,11 +341,25 @@ func (r Resource) Delete(ctx context.Context, request resource.DeleteRequest, re
 		return
 	}
 
-	if slices.Contains(r.FailOnDelete, resource.GetId()) {
-		response.Diagnostics.Append(diag.NewErrorDiagnostic("failed to delete resource", "forced failure"))
+	behaviours, diags := loadBehaviours(r.Behaviours, r.Faults)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	failure, diags := evaluateBehaviours(ctx, behaviours, r.Counter, behaviour.Delete, r.Name, resource.GetId())
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
 		return
 	}
 
+	if failure != nil {
+		response.Diagnostics.Append(failure.ToDiagnostic("failed to delete resource", "forced failure"))
+		if response.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	if err := r.Client.DeleteResource(ctx, resource.GetId()); err != nil {
 		response.Diagnostics.AddError("failed to delete resource", err.Error())
 		return
@@ -188,35 +369,495 @@ func (r Resource) Delete(ctx context.Context, request resource.DeleteRequest, re
 	response.Diagnostics.Append(response.Identity.Set(ctx, resource.Identity())...)
 }
 
+// readAfterWrite reads back the resource that has just been written, as
+// providers for eventually consistent APIs often do, and sets whatever it
+// reads as the new state. This means Terraform sees any inconsistency in the
+// result of the write. It returns false if the read failed, or if it reported
+// the resource as missing, in which case the state is removed.
+func (r Resource) readAfterWrite(ctx context.Context, written *data.Resource, typ tftypes.Object, state *tfsdk.State, diags *diag.Diagnostics) bool {
+	read, err := r.Client.ReadResource(ctx, written.GetId())
+	if err != nil {
+		if os.IsNotExist(err) {
+			state.RemoveResource(ctx)
+			return false
+		}
+		// The write still happened, so Terraform should still record it.
+		diags.Append(state.Set(ctx, written)...)
+		diags.AddError("failed to read resource after write", err.Error())
+		return false
+	}
+
+	if read == nil {
+		// The client is telling us to rely on the state, which is what we
+		// just wrote.
+		read = written
+	}
+
+	diags.Append(state.Set(ctx, read.WithType(typ))...)
+	return true
+}
+
 func (r Resource) ImportState(ctx context.Context, request resource.ImportStateRequest, response *resource.ImportStateResponse) {
+	id := request.ID
+	if len(id) == 0 && request.Identity != nil {
+		// Then we're importing by identity instead of by id.
+		var identity types.String
+		response.Diagnostics.Append(request.Identity.GetAttribute(ctx, path.Root("id"), &identity)...)
+		if response.Diagnostics.HasError() {
+			return
+		}
+		id = identity.ValueString()
+	}
+
+	behaviours, diags := loadBehaviours(r.Behaviours, r.Faults)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	failure, diags := evaluateBehaviours(ctx, behaviours, r.Counter, behaviour.Import, r.Name, id)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	if failure != nil {
+		response.Diagnostics.Append(failure.ToDiagnostic("failed to import resource", "forced failure"))
+		if response.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	deferral, diags := evaluateDeferral(behaviours, behaviour.Import, r.Name, id, tftypes.Value{}, request.ClientCapabilities.DeferralAllowed)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), request, response)
+	if deferral != nil {
+		response.Deferred = resourceDeferred(deferral)
+	}
+}
+
+func (r Resource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	upgraders := make(map[int64]resource.StateUpgrader, r.InternalSchema.Version)
+	for version := int64(0); version < r.InternalSchema.Version; version++ {
+		upgraders[version] = resource.StateUpgrader{
+			StateUpgrader: func(ctx context.Context, request resource.UpgradeStateRequest, response *resource.UpgradeStateResponse) {
+				r.upgradeState(ctx, version, request, response)
+			},
+		}
+	}
+	return upgraders
+}
+
+// upgradeState upgrades state recorded by the given version of the schema to
+// the current version. We don't know the earlier schemas, so the upgrade steps
+// work directly on the JSON state recorded by Terraform.
+func (r Resource) upgradeState(ctx context.Context, version int64, request resource.UpgradeStateRequest, response *resource.UpgradeStateResponse) {
+	if request.RawState == nil || request.RawState.JSON == nil {
+		response.Diagnostics.AddError("failed to upgrade resource state", "only state recorded in the JSON format can be upgraded")
+		return
+	}
+
+	var state map[string]any
+	decoder := json.NewDecoder(bytes.NewReader(request.RawState.JSON))
+	decoder.UseNumber()
+	if err := decoder.Decode(&state); err != nil {
+		response.Diagnostics.AddError("failed to upgrade resource state", err.Error())
+		return
+	}
+
+	if err := r.InternalSchema.UpgradeState(version, state); err != nil {
+		response.Diagnostics.AddError("failed to upgrade resource state", err.Error())
+		return
+	}
+
+	jsonData, err := json.Marshal(state)
+	if err != nil {
+		response.Diagnostics.AddError("failed to upgrade resource state", err.Error())
+		return
+	}
+
+	// We don't ignore attributes missing from the current schema, so any
+	// attribute that was removed without a matching upgrade step is reported.
+	raw := tfprotov6.RawState{JSON: jsonData}
+	value, err := raw.Unmarshal(response.State.Schema.Type().TerraformType(ctx))
+	if err != nil {
+		response.Diagnostics.AddError("failed to upgrade resource state", fmt.Sprintf("the upgraded state doesn't match version %d of the schema: %v", r.InternalSchema.Version, err))
+		return
+	}
+	response.State.Raw = value
+
+	upgraded := &data.Resource{}
+	response.Diagnostics.Append(response.State.Get(ctx, &upgraded)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	upgraded.ResourceType = r.Name
+
+	// The resource directory holds the resource in the old format too, so we
+	// upgrade it at the same time. Resources that are missing are left for the
+	// next refresh to remove.
+	if r.Client == nil {
+		return
+	}
+	if err := r.Client.UpdateResource(ctx, upgraded); err != nil && !os.IsNotExist(err) {
+		response.Diagnostics.AddError("failed to upgrade resource", err.Error())
+	}
+}
+
+func (r Resource) MoveState(ctx context.Context) []resource.StateMover {
+	var movers []resource.StateMover
+	for _, move := range r.InternalSchema.MovedFrom {
+		movers = append(movers, resource.StateMover{
+			StateMover: func(ctx context.Context, request resource.MoveStateRequest, response *resource.MoveStateResponse) {
+				r.moveState(ctx, move, request, response)
+			},
+		})
+	}
+	return movers
+}
+
+// moveState moves a resource of another type into this one, if the source
+// matches the given move. Otherwise, the response is left empty so Terraform
+// can try the next move.
+func (r Resource) moveState(ctx context.Context, move schema.Move, request resource.MoveStateRequest, response *resource.MoveStateResponse) {
+	if request.SourceTypeName != move.ResourceType || !strings.HasSuffix(request.SourceProviderAddress, "/tfcoremock") {
+		return
+	}
+
+	if request.SourceRawState == nil || request.SourceRawState.JSON == nil {
+		response.Diagnostics.AddError("failed to move resource state", "only state recorded in the JSON format can be moved")
+		return
+	}
+
+	var state map[string]any
+	decoder := json.NewDecoder(bytes.NewReader(request.SourceRawState.JSON))
+	decoder.UseNumber()
+	if err := decoder.Decode(&state); err != nil {
+		response.Diagnostics.AddError("failed to move resource state", err.Error())
+		return
+	}
+
+	move.Apply(state)
+
+	jsonData, err := json.Marshal(state)
+	if err != nil {
+		response.Diagnostics.AddError("failed to move resource state", err.Error())
+		return
+	}
+
+	// Unlike upgrades, attributes this resource doesn't have are dropped
+	// silently as the source is a different resource type.
+	raw := tfprotov6.RawState{JSON: jsonData}
+	value, err := raw.UnmarshalWithOpts(response.TargetState.Schema.Type().TerraformType(ctx), tfprotov6.UnmarshalOpts{
+		ValueFromJSONOpts: tftypes.ValueFromJSONOpts{
+			IgnoreUndefinedAttributes: true,
+		},
+	})
+	if err != nil {
+		response.Diagnostics.AddError("failed to move resource state", fmt.Sprintf("the state of %s doesn't match %s: %v", move.ResourceType, r.Name, err))
+		return
+	}
+	response.TargetState.Raw = value
+
+	moved := &data.Resource{}
+	response.Diagnostics.Append(response.TargetState.Get(ctx, &moved)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	moved.ResourceType = r.Name
+	response.Diagnostics.Append(response.TargetIdentity.Set(ctx, moved.Identity())...)
+
+	// The resource directory is keyed by ID, so the same file now holds the
+	// resource with its new type. Resources that are missing are left for the
+	// next refresh to remove.
+	if r.Client == nil {
+		return
+	}
+	if err := r.Client.UpdateResource(ctx, moved); err != nil && !os.IsNotExist(err) {
+		response.Diagnostics.AddError("failed to move resource", err.Error())
+	}
 }
 
 func (r Resource) ModifyPlan(ctx context.Context, request resource.ModifyPlanRequest, response *resource.ModifyPlanResponse) {
+	behaviours, diags := loadBehaviours(r.Behaviours, r.Faults)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	if request.Plan.Raw.IsNull() {
+		// Then this resource is being destroyed. We can't defer destroy plans,
+		// but we can still make them fail.
+		res := &data.Resource{}
+		response.Diagnostics.Append(request.State.Get(ctx, &res)...)
+		if response.Diagnostics.HasError() {
+			return
+		}
+
+		failure, diags := evaluateBehaviours(ctx, behaviours, r.Counter, behaviour.PlanDelete, r.Name, res.GetId())
+		response.Diagnostics.Append(diags...)
+		if failure != nil {
+			response.Diagnostics.Append(failure.ToDiagnostic("failed to plan resource", "forced failure"))
+		}
+		return
+	}
+
 	res := &data.Resource{}
 	response.Diagnostics.Append(request.Plan.Get(ctx, &res)...)
 	if response.Diagnostics.HasError() {
 		return
 	}
 
-	if _, ok := res.Values["id"]; !ok {
-		// then resource is unknown or something, which means we can't check
-		// it
+	// If the id is unknown, then we leave it empty. This means behaviours
+	// that only target resource types will still apply.
+	var id string
+	if value, ok := res.Values["id"]; ok {
+		id = *value.String
+	}
+
+	planned, err := r.renderTemplates(request.Config.Raw, response.Plan.Raw)
+	if err != nil {
+		response.Diagnostics.AddError("failed to render templates", err.Error())
 		return
 	}
+	response.Plan.Raw = planned
 
-	id := res.GetId()
-	if slices.Contains(r.DeferChanges, id) {
-		// Then we want to defer this change!
+	operation, err := r.planOperation(request.State.Raw, response.Plan.Raw)
+	if err != nil {
+		response.Diagnostics.AddError("failed to classify plan", err.Error())
+		return
+	}
 
-		if !request.ClientCapabilities.DeferralAllowed {
-			response.Diagnostics.AddAttributeError(path.Root("id"), "Invalid resource deferral", "This `id` was marked as \"should be deferred\", but the current version of Terraform does not support deferrals.")
+	failure, diags := evaluateBehaviours(ctx, behaviours, r.Counter, operation, r.Name, id)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	if failure != nil {
+		response.Diagnostics.Append(failure.ToDiagnostic("failed to plan resource", "forced failure"))
+		if response.Diagnostics.HasError() {
 			return
 		}
+	}
+
+	if operation != behaviour.Plan {
+		planned, err := r.markKnownAfterApply(request.Config.Raw, response.Plan.Raw)
+		if err != nil {
+			response.Diagnostics.AddError("failed to mark values as known after apply", err.Error())
+			return
+		}
+
+		// Render the templates again, so any that refer to values that are
+		// now unknown are left unknown too.
+		planned, err = r.renderTemplates(request.Config.Raw, planned)
+		if err != nil {
+			response.Diagnostics.AddError("failed to render templates", err.Error())
+			return
+		}
+		response.Plan.Raw = planned
+	}
+
+	deferral, diags := evaluateDeferral(behaviours, operation, r.Name, id, request.Config.Raw, request.ClientCapabilities.DeferralAllowed)
+	response.Diagnostics.Append(diags...)
+	if deferral != nil {
+		// Then we want to defer this change!
+		response.Deferred = resourceDeferred(deferral)
+	}
+}
+
+// removeWriteOnly returns the value with every write-only attribute set to
+// null.
+func (r Resource) removeWriteOnly(value tftypes.Value) (tftypes.Value, error) {
+	return tftypes.Transform(value, func(path *tftypes.AttributePath, value tftypes.Value) (tftypes.Value, error) {
+		if !r.InternalSchema.WriteOnly(path) {
+			return value, nil
+		}
+		return tftypes.NewValue(value.Type(), nil), nil
+	})
+}
+
+// hashWriteOnly returns a JSON object holding the SHA-256 hash of every
+// write-only value set in the configuration, keyed by the path to the value.
+// This is kept in the private state, so tests can tell when a write-only
+// value has changed without the value itself being stored. It returns nil if
+// there are no write-only values.
+func (r Resource) hashWriteOnly(config tftypes.Value) ([]byte, error) {
+	hashes := make(map[string]string)
+	err := tftypes.Walk(config, func(path *tftypes.AttributePath, value tftypes.Value) (bool, error) {
+		if !r.InternalSchema.WriteOnly(path) {
+			return true, nil
+		}
+		if value.IsNull() {
+			return false, nil
+		}
+
+		converted, err := data.FromTerraform5Value(value)
+		if err != nil {
+			return false, err
+		}
+		jsonData, err := json.Marshal(converted)
+		if err != nil {
+			return false, err
+		}
+		hash := sha256.Sum256(jsonData)
+		hashes[writeOnlyPath(path)] = hex.EncodeToString(hash[:])
+		return false, nil
+	})
+	if err != nil || len(hashes) == 0 {
+		return nil, err
+	}
+	return json.Marshal(hashes)
+}
+
+// writeOnlyPath formats the path to a write-only value, for example
+// `endpoint[0].password`.
+func writeOnlyPath(path *tftypes.AttributePath) string {
+	var builder strings.Builder
+	for _, step := range path.Steps() {
+		switch step := step.(type) {
+		case tftypes.AttributeName:
+			if builder.Len() > 0 {
+				builder.WriteString(".")
+			}
+			builder.WriteString(string(step))
+		case tftypes.ElementKeyInt:
+			fmt.Fprintf(&builder, "[%d]", int64(step))
+		case tftypes.ElementKeyString:
+			fmt.Fprintf(&builder, "[%q]", string(step))
+		}
+	}
+	return builder.String()
+}
+
+// markKnownAfterApply returns the planned value with every attribute that is
+// known after apply set to unknown, unless the configuration sets a value for
+// it.
+func (r Resource) markKnownAfterApply(config tftypes.Value, planned tftypes.Value) (tftypes.Value, error) {
+	return tftypes.Transform(planned, func(path *tftypes.AttributePath, value tftypes.Value) (tftypes.Value, error) {
+		if !r.InternalSchema.KnownAfterApply(path) {
+			return value, nil
+		}
+
+		if configured(config, path) {
+			return value, nil
+		}
+		return tftypes.NewValue(value.Type(), tftypes.UnknownValue), nil
+	})
+}
+
+// renderTemplates returns the planned value with every attribute that has a
+// template set to the rendered template, unless the configuration sets a value
+// for it. Templates that refer to unknown values are left unknown, as are
+// templated attributes that are known after apply and already unknown.
+func (r Resource) renderTemplates(config tftypes.Value, planned tftypes.Value) (tftypes.Value, error) {
+	return tftypes.Transform(planned, func(path *tftypes.AttributePath, value tftypes.Value) (tftypes.Value, error) {
+		attribute := r.InternalSchema.AttributeAt(path)
+		if attribute == nil || len(attribute.Template) == 0 || configured(config, path) {
+			return value, nil
+		}
+		if attribute.KnownAfterApply && !value.IsKnown() {
+			return value, nil
+		}
+
+		rendered, known, err := attribute.Template.Render(func(reference []string) (string, bool, error) {
+			path := tftypes.NewAttributePath()
+			for _, name := range reference {
+				path = path.WithAttributeName(name)
+			}
+
+			referenced, _, err := tftypes.WalkAttributePath(planned, path)
+			if err != nil {
+				// Then we stepped through a null object, so the referenced
+				// value is null too.
+				return "", true, nil
+			}
+			if referenced, ok := referenced.(tftypes.Value); ok {
+				return render(referenced)
+			}
+			return "", true, nil
+		})
+		if err != nil {
+			return value, err
+		}
+
+		if !known {
+			return tftypes.NewValue(tftypes.String, tftypes.UnknownValue), nil
+		}
+		return tftypes.NewValue(tftypes.String, rendered), nil
+	})
+}
+
+// render converts a primitive value into the string it is written as within a
+// template, and returns false if the value is unknown.
+func render(value tftypes.Value) (string, bool, error) {
+	if !value.IsKnown() {
+		return "", false, nil
+	}
+	if value.IsNull() {
+		return "", true, nil
+	}
+
+	switch {
+	case value.Type().Is(tftypes.Bool):
+		var b bool
+		if err := value.As(&b); err != nil {
+			return "", false, err
+		}
+		return strconv.FormatBool(b), true, nil
+	case value.Type().Is(tftypes.Number):
+		var n big.Float
+		if err := value.As(&n); err != nil {
+			return "", false, err
+		}
+		return n.Text('f', -1), true, nil
+	case value.Type().Is(tftypes.String):
+		var s string
+		if err := value.As(&s); err != nil {
+			return "", false, err
+		}
+		return s, true, nil
+	default:
+		return "", false, fmt.Errorf("can't render %s values in templates", value.Type())
+	}
+}
+
+// configured returns true if the configuration sets a value at the given path.
+func configured(config tftypes.Value, path *tftypes.AttributePath) bool {
+	value, _, err := tftypes.WalkAttributePath(config, path)
+	if err != nil {
+		return false
+	}
+	if value, ok := value.(tftypes.Value); ok {
+		return !value.IsNull()
+	}
+	return false
+}
+
+// planOperation works out which kind of plan is being made for a resource that
+// isn't being destroyed.
+func (r Resource) planOperation(state tftypes.Value, planned tftypes.Value) (behaviour.Operation, error) {
+	if state.IsNull() {
+		return behaviour.PlanCreate, nil
+	}
+
+	diffs, err := state.Diff(planned)
+	if err != nil {
+		return "", err
+	}
+
+	if len(diffs) == 0 {
+		return behaviour.Plan, nil
+	}
 
-		response.Deferred = &resource.Deferred{
-			// not technically true, but the closest we have
-			Reason: resource.DeferredReasonResourceConfigUnknown,
+	for _, diff := range diffs {
+		if r.InternalSchema.RequiresReplace(diff.Path) {
+			return behaviour.PlanReplace, nil
 		}
 	}
+	return behaviour.PlanUpdate, nil
 }
@@ -0,0 +1,195 @@
+// Copyright IBM Corp. 2022, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// The static functions are always supplied by the provider, alongside any
+// functions defined in the dynamic resources file. They exist to exercise the
+// different paths Terraform takes when calling functions.
+
+var (
+	_ function.Function = EchoFunction{}
+	_ function.Function = FailFunction{}
+	_ function.Function = SensitiveEchoFunction{}
+	_ function.Function = UnknownPassthroughFunction{}
+	_ function.Function = VariadicCountFunction{}
+)
+
+// EchoFunction returns its argument unchanged.
+type EchoFunction struct{}
+
+func (f EchoFunction) Metadata(ctx context.Context, request function.MetadataRequest, response *function.MetadataResponse) {
+	response.Name = "echo"
+}
+
+func (f EchoFunction) Definition(ctx context.Context, request function.DefinitionRequest, response *function.DefinitionResponse) {
+	response.Definition = function.Definition{
+		Summary:             "Returns its argument unchanged",
+		Description:         "Returns the given value, of any type, unchanged. Null values are returned as they are.",
+		MarkdownDescription: "Returns the given value, of any type, unchanged. Null values are returned as they are.",
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name:                "value",
+				Description:         "The value to return.",
+				MarkdownDescription: "The value to return.",
+				AllowNullValue:      true,
+			},
+		},
+		Return: function.DynamicReturn{},
+	}
+}
+
+func (f EchoFunction) Run(ctx context.Context, request function.RunRequest, response *function.RunResponse) {
+	echo(ctx, request, response)
+}
+
+// FailFunction always fails, and blames the argument at the given index.
+type FailFunction struct{}
+
+func (f FailFunction) Metadata(ctx context.Context, request function.MetadataRequest, response *function.MetadataResponse) {
+	response.Name = "fail"
+}
+
+func (f FailFunction) Definition(ctx context.Context, request function.DefinitionRequest, response *function.DefinitionResponse) {
+	response.Definition = function.Definition{
+		Summary:             "Always fails",
+		Description:         "Always returns an error with the given message, attributed to the argument at the given zero-based index. The index counts every argument, including the index and message themselves, so any additional arguments start at index 2.",
+		MarkdownDescription: "Always returns an error with the given `message`, attributed to the argument at the given zero-based `index`. The index counts every argument, including the `index` and `message` themselves, so any additional arguments start at index 2.",
+		Parameters: []function.Parameter{
+			function.Int64Parameter{
+				Name:                "index",
+				Description:         "The index of the argument the error is attributed to.",
+				MarkdownDescription: "The index of the argument the error is attributed to.",
+			},
+			function.StringParameter{
+				Name:                "message",
+				Description:         "The message of the error.",
+				MarkdownDescription: "The message of the error.",
+			},
+		},
+		VariadicParameter: function.DynamicParameter{
+			Name:                "values",
+			Description:         "Additional arguments, which the error can be attributed to.",
+			MarkdownDescription: "Additional arguments, which the error can be attributed to.",
+			AllowNullValue:      true,
+		},
+		Return: function.DynamicReturn{},
+	}
+}
+
+func (f FailFunction) Run(ctx context.Context, request function.RunRequest, response *function.RunResponse) {
+	var index int64
+	var message string
+	var values types.Tuple
+	if response.Error = request.Arguments.Get(ctx, &index, &message, &values); response.Error != nil {
+		return
+	}
+	response.Error = function.NewArgumentFuncError(index, message)
+}
+
+// SensitiveEchoFunction returns its argument unchanged. It behaves exactly
+// like EchoFunction, as Terraform never sends sensitive or other marks to the
+// provider and instead applies the marks of the arguments to the result.
+type SensitiveEchoFunction struct{}
+
+func (f SensitiveEchoFunction) Metadata(ctx context.Context, request function.MetadataRequest, response *function.MetadataResponse) {
+	response.Name = "sensitive_echo"
+}
+
+func (f SensitiveEchoFunction) Definition(ctx context.Context, request function.DefinitionRequest, response *function.DefinitionResponse) {
+	response.Definition = function.Definition{
+		Summary:             "Returns its argument unchanged, along with its marks",
+		Description:         "Returns the given value, of any type, unchanged. Terraform applies any marks on the argument, such as sensitive, to the result, so this can be used to test how marks pass through function calls.",
+		MarkdownDescription: "Returns the given value, of any type, unchanged. Terraform applies any marks on the argument, such as `sensitive`, to the result, so this can be used to test how marks pass through function calls.",
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name:                "value",
+				Description:         "The value to return.",
+				MarkdownDescription: "The value to return.",
+				AllowNullValue:      true,
+			},
+		},
+		Return: function.DynamicReturn{},
+	}
+}
+
+func (f SensitiveEchoFunction) Run(ctx context.Context, request function.RunRequest, response *function.RunResponse) {
+	echo(ctx, request, response)
+}
+
+// UnknownPassthroughFunction returns its argument unchanged, and unlike
+// EchoFunction it is called even when the argument is unknown.
+type UnknownPassthroughFunction struct{}
+
+func (f UnknownPassthroughFunction) Metadata(ctx context.Context, request function.MetadataRequest, response *function.MetadataResponse) {
+	response.Name = "unknown_passthrough"
+}
+
+func (f UnknownPassthroughFunction) Definition(ctx context.Context, request function.DefinitionRequest, response *function.DefinitionResponse) {
+	response.Definition = function.Definition{
+		Summary:             "Returns its argument unchanged, even if it is unknown",
+		Description:         "Returns the given value, of any type, unchanged. Unlike echo, the provider is called even when the value is unknown or contains unknown values, and returns them as they are.",
+		MarkdownDescription: "Returns the given value, of any type, unchanged. Unlike `echo`, the provider is called even when the value is unknown or contains unknown values, and returns them as they are.",
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name:                "value",
+				Description:         "The value to return.",
+				MarkdownDescription: "The value to return.",
+				AllowNullValue:      true,
+				AllowUnknownValues:  true,
+			},
+		},
+		Return: function.DynamicReturn{},
+	}
+}
+
+func (f UnknownPassthroughFunction) Run(ctx context.Context, request function.RunRequest, response *function.RunResponse) {
+	echo(ctx, request, response)
+}
+
+// VariadicCountFunction returns the number of arguments it was called with.
+type VariadicCountFunction struct{}
+
+func (f VariadicCountFunction) Metadata(ctx context.Context, request function.MetadataRequest, response *function.MetadataResponse) {
+	response.Name = "variadic_count"
+}
+
+func (f VariadicCountFunction) Definition(ctx context.Context, request function.DefinitionRequest, response *function.DefinitionResponse) {
+	response.Definition = function.Definition{
+		Summary:             "Counts its arguments",
+		Description:         "Returns the number of arguments, of any type, that the function was called with. Null and unknown arguments are counted too.",
+		MarkdownDescription: "Returns the number of arguments, of any type, that the function was called with. Null and unknown arguments are counted too.",
+		VariadicParameter: function.DynamicParameter{
+			Name:                "values",
+			Description:         "The arguments to count.",
+			MarkdownDescription: "The arguments to count.",
+			AllowNullValue:      true,
+			AllowUnknownValues:  true,
+		},
+		Return: function.Int64Return{},
+	}
+}
+
+func (f VariadicCountFunction) Run(ctx context.Context, request function.RunRequest, response *function.RunResponse) {
+	var values types.Tuple
+	if response.Error = request.Arguments.Get(ctx, &values); response.Error != nil {
+		return
+	}
+	response.Error = response.Result.Set(ctx, types.Int64Value(int64(len(values.Elements()))))
+}
+
+// echo returns the single argument of a function unchanged.
+func echo(ctx context.Context, request function.RunRequest, response *function.RunResponse) {
+	var value types.Dynamic
+	if response.Error = request.Arguments.Get(ctx, &value); response.Error != nil {
+		return
+	}
+	response.Error = response.Result.Set(ctx, value)
+}
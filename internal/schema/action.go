@@ -6,6 +6,7 @@ package schema
 import (
 	"github.com/hashicorp/terraform-plugin-framework/action/schema"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 )
 
 var (
@@ -55,6 +56,7 @@ func asActionFloat(attribute Attribute) (*schema.Attribute, error) {
 		Optional:            attribute.Optional || attribute.Computed,
 		Description:         attribute.Description,
 		MarkdownDescription: attribute.MarkdownDescription,
+		Validators:          validatorsAs[validator.Float64](attribute.Validators),
 	}
 
 	var out schema.Attribute
@@ -72,6 +74,7 @@ func asActionInteger(attribute Attribute) (*schema.Attribute, error) {
 		Optional:            attribute.Optional || attribute.Computed,
 		Description:         attribute.Description,
 		MarkdownDescription: attribute.MarkdownDescription,
+		Validators:          validatorsAs[validator.Int64](attribute.Validators),
 	}
 
 	var out schema.Attribute
@@ -89,6 +92,7 @@ func asActionNumber(attribute Attribute) (*schema.Attribute, error) {
 		Optional:            attribute.Optional || attribute.Computed,
 		Description:         attribute.Description,
 		MarkdownDescription: attribute.MarkdownDescription,
+		Validators:          validatorsAs[validator.Number](attribute.Validators),
 	}
 
 	var out schema.Attribute
@@ -106,6 +110,7 @@ func asActionString(attribute Attribute) (*schema.Attribute, error) {
 		Optional:            attribute.Optional || attribute.Computed,
 		Description:         attribute.Description,
 		MarkdownDescription: attribute.MarkdownDescription,
+		Validators:          validatorsAs[validator.String](attribute.Validators),
 	}
 
 	var out schema.Attribute
@@ -123,6 +128,7 @@ func asActionList(attribute Attribute) (*schema.Attribute, error) {
 		Optional:            attribute.Optional || attribute.Computed,
 		Description:         attribute.Description,
 		MarkdownDescription: attribute.MarkdownDescription,
+		Validators:          validatorsAs[validator.List](attribute.Validators),
 	}
 
 	elem, err := ToTerraformAttribute(*attribute.List, actions)
@@ -146,6 +152,7 @@ func asActionNestedList(attribute Attribute) (*schema.Attribute, error) {
 		Optional:            attribute.Optional || attribute.Computed,
 		Description:         attribute.Description,
 		MarkdownDescription: attribute.MarkdownDescription,
+		Validators:          validatorsAs[validator.List](attribute.Validators),
 	}
 
 	var err error
@@ -168,6 +175,7 @@ func asActionMap(attribute Attribute) (*schema.Attribute, error) {
 		Optional:            attribute.Optional || attribute.Computed,
 		Description:         attribute.Description,
 		MarkdownDescription: attribute.MarkdownDescription,
+		Validators:          validatorsAs[validator.Map](attribute.Validators),
 	}
 
 	elem, err := ToTerraformAttribute(*attribute.Map, actions)
@@ -191,6 +199,7 @@ func asActionNestedMap(attribute Attribute) (*schema.Attribute, error) {
 		Optional:            attribute.Optional || attribute.Computed,
 		Description:         attribute.Description,
 		MarkdownDescription: attribute.MarkdownDescription,
+		Validators:          validatorsAs[validator.Map](attribute.Validators),
 	}
 
 	var err error
@@ -213,6 +222,7 @@ func asActionSet(attribute Attribute) (*schema.Attribute, error) {
 		Optional:            attribute.Optional || attribute.Computed,
 		Description:         attribute.Description,
 		MarkdownDescription: attribute.MarkdownDescription,
+		Validators:          validatorsAs[validator.Set](attribute.Validators),
 	}
 
 	elem, err := ToTerraformAttribute(*attribute.Set, actions)
@@ -236,6 +246,7 @@ func asActionNestedSet(attribute Attribute) (*schema.Attribute, error) {
 		Optional:            attribute.Optional || attribute.Computed,
 		Description:         attribute.Description,
 		MarkdownDescription: attribute.MarkdownDescription,
+		Validators:          validatorsAs[validator.Set](attribute.Validators),
 	}
 
 	var err error
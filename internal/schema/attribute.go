@@ -8,6 +8,7 @@ import (
 
 	action_schema "github.com/hashicorp/terraform-plugin-framework/action/schema"
 	datasource_schema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	ephemeral_schema "github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
 	resource_schema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/pkg/errors"
 
@@ -37,6 +38,28 @@ type Attribute struct {
 
 	Value *data.Value `json:"value,omitempty"`
 
+	// Generator creates the value for computed attributes when the resource is
+	// created or updated, instead of using a fixed Value.
+	Generator *Generator `json:"generator,omitempty"`
+
+	// Template derives the value for computed string attributes from the other
+	// values in the resource, for example `arn:mock:${name}`.
+	Template Template `json:"template,omitempty"`
+
+	// KnownAfterApply marks computed attributes as unknown whenever the
+	// resource is planned to change, so their value is only known once the
+	// change has been applied.
+	KnownAfterApply bool `json:"known_after_apply"`
+
+	// WriteOnly marks attributes whose values are sent in the configuration
+	// but never stored in the plan, the state, or the resource directory.
+	WriteOnly bool `json:"write_only"`
+
+	// Validators check the value of the attribute in the configuration of
+	// resources, ephemeral resources, and actions, and the value data sources
+	// read from the data directory.
+	Validators []Validator `json:"validators,omitempty"`
+
 	List   *Attribute           `json:"list,omitempty"`
 	Map    *Attribute           `json:"map,omitempty"`
 	Object map[string]Attribute `json:"object,omitempty"`
@@ -140,6 +163,18 @@ func attributesToTerraformDataSourceAttributes(attributes map[string]Attribute)
 	return tfAttributes, nil
 }
 
+func attributesToTerraformEphemeralAttributes(attributes map[string]Attribute) (map[string]ephemeral_schema.Attribute, error) {
+	tfAttributes := make(map[string]ephemeral_schema.Attribute)
+	for name, attribute := range attributes {
+		attribute, err := ToTerraformAttribute(attribute, ephemeralResources)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to create attribute '%s'", name)
+		}
+		tfAttributes[name] = *attribute
+	}
+	return tfAttributes, nil
+}
+
 func attributesToTerraformActionAttributes(attributes map[string]Attribute) (map[string]action_schema.Attribute, error) {
 	tfAttributes := make(map[string]action_schema.Attribute)
 	for name, attribute := range attributes {
@@ -151,3 +186,133 @@ func attributesToTerraformActionAttributes(attributes map[string]Attribute) (map
 	}
 	return tfAttributes, nil
 }
+
+// validateComputed checks that the attribute, and any nested attributes, are
+// computed if they have been marked as known after apply or have a generator
+// or a template.
+func (a Attribute) validateComputed() error {
+	if a.KnownAfterApply && !a.Computed {
+		return errors.New("only computed attributes can be known after apply")
+	}
+
+	if a.Generator != nil {
+		if !a.Computed {
+			return errors.New("only computed attributes can have a generator")
+		}
+		if a.Value != nil {
+			return errors.New("only one of value and generator can be set")
+		}
+		if err := a.Generator.Validate(a.Type); err != nil {
+			return fmt.Errorf("invalid generator: %w", err)
+		}
+	}
+
+	if len(a.Template) > 0 {
+		if !a.Computed {
+			return errors.New("only computed attributes can have a template")
+		}
+		if a.Value != nil || a.Generator != nil {
+			return errors.New("only one of value, generator and template can be set")
+		}
+		if a.Type != String {
+			return errors.New("only string attributes can have a template")
+		}
+		if _, err := a.Template.References(); err != nil {
+			return fmt.Errorf("invalid template: %w", err)
+		}
+	}
+
+	for _, nested := range []*Attribute{a.List, a.Map, a.Set} {
+		if nested != nil {
+			if err := nested.validateComputed(); err != nil {
+				return err
+			}
+		}
+	}
+	for name, nested := range a.Object {
+		if err := nested.validateComputed(); err != nil {
+			return fmt.Errorf("attribute %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// validateWriteOnly checks that write-only attributes aren't computed, don't
+// force replacement, and aren't sets or within sets. Every attribute nested
+// within a write-only attribute must be write-only too.
+func (a Attribute) validateWriteOnly(parent bool, inSet bool) error {
+	if a.WriteOnly {
+		if a.Computed {
+			return errors.New("write-only attributes cannot be computed")
+		}
+		if a.Replace {
+			return errors.New("write-only attributes cannot force replacement as they are never planned")
+		}
+		if a.Type == Set || inSet {
+			return errors.New("write-only attributes cannot be sets or within sets")
+		}
+	}
+	if parent && !a.WriteOnly {
+		return errors.New("attributes within write-only attributes must also be write-only")
+	}
+
+	if a.SkipNestedMetadata {
+		// Then any nested attributes are just part of the type, and can't be
+		// marked as write-only themselves.
+		return nil
+	}
+
+	objects := []map[string]Attribute{a.Object}
+	for _, element := range []*Attribute{a.List, a.Map, a.Set} {
+		if element != nil {
+			objects = append(objects, element.Object)
+		}
+	}
+	for _, object := range objects {
+		for name, nested := range object {
+			if err := nested.validateWriteOnly(a.WriteOnly, inSet || a.Type == Set); err != nil {
+				return fmt.Errorf("attribute %s: %w", name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// validateValidators checks the validators of the attribute, and any nested
+// attributes, can be applied to them. Validators can only be set on attributes
+// Terraform validates individually, so not on the elements of lists, maps, and
+// sets, or within objects that skip nested metadata.
+func (a Attribute) validateValidators(typeOnly bool) error {
+	if len(a.Validators) > 0 {
+		if typeOnly {
+			return errors.New("validators cannot be set on elements or within objects that skip nested metadata")
+		}
+		if !a.Optional && !a.Required {
+			return errors.New("only optional or required attributes can have validators")
+		}
+	}
+	for ix, v := range a.Validators {
+		if err := v.Validate(a.Type); err != nil {
+			return fmt.Errorf("validators[%d]: %w", ix, err)
+		}
+	}
+
+	nested := typeOnly || a.SkipNestedMetadata
+	for _, element := range []*Attribute{a.List, a.Map, a.Set} {
+		if element == nil {
+			continue
+		}
+		if len(element.Validators) > 0 {
+			return errors.New("validators cannot be set on elements or within objects that skip nested metadata")
+		}
+		if err := element.validateValidators(nested || element.Type != Object); err != nil {
+			return err
+		}
+	}
+	for name, nested := range a.Object {
+		if err := nested.validateValidators(typeOnly || a.SkipNestedMetadata); err != nil {
+			return fmt.Errorf("attribute %s: %w", name, err)
+		}
+	}
+	return nil
+}
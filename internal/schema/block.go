@@ -8,6 +8,7 @@ import (
 
 	action_schema "github.com/hashicorp/terraform-plugin-framework/action/schema"
 	datasource_schema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	ephemeral_schema "github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
 	resource_schema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/pkg/errors"
 )
@@ -142,6 +143,44 @@ func blocksToTerraformDataSourceBlocks(blocks map[string]Block) (map[string]data
 	return tfBlocks, nil
 }
 
+func blocksToTerraformEphemeralBlocks(blocks map[string]Block) (map[string]ephemeral_schema.Block, error) {
+	toListBlock := func(block Block, blocks map[string]ephemeral_schema.Block, attributes map[string]ephemeral_schema.Attribute) *ephemeral_schema.Block {
+		var tfBlock ephemeral_schema.Block
+		tfBlock = ephemeral_schema.ListNestedBlock{
+			Description:         block.Description,
+			MarkdownDescription: block.MarkdownDescription,
+			NestedObject: ephemeral_schema.NestedBlockObject{
+				Attributes: attributes,
+				Blocks:     blocks,
+			},
+		}
+		return &tfBlock
+	}
+
+	toSetBlock := func(block Block, blocks map[string]ephemeral_schema.Block, attributes map[string]ephemeral_schema.Attribute) *ephemeral_schema.Block {
+		var tfBlock ephemeral_schema.Block
+		tfBlock = ephemeral_schema.SetNestedBlock{
+			Description:         block.Description,
+			MarkdownDescription: block.MarkdownDescription,
+			NestedObject: ephemeral_schema.NestedBlockObject{
+				Attributes: attributes,
+				Blocks:     blocks,
+			},
+		}
+		return &tfBlock
+	}
+
+	tfBlocks := make(map[string]ephemeral_schema.Block)
+	for name, block := range blocks {
+		block, err := ToTerraformBlock(block, toListBlock, toSetBlock, ephemeralResources)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to create block '%s'", name)
+		}
+		tfBlocks[name] = *block
+	}
+	return tfBlocks, nil
+}
+
 func blocksToTerraformActionBlocks(blocks map[string]Block) (map[string]action_schema.Block, error) {
 	toListBlock := func(block Block, blocks map[string]action_schema.Block, attributes map[string]action_schema.Attribute) *action_schema.Block {
 		var tfBlock action_schema.Block
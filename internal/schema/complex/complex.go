@@ -37,6 +37,12 @@ func Schema(maxDepth int) schema.Schema {
 		MarkdownDescription: strings.ReplaceAll(fmt.Sprintf(markdownDescription, maxDepth), "''", "`"),
 		Attributes:          attributes(0, maxDepth),
 		Blocks:              blocks(0, maxDepth),
+
+		// The complex resource has every attribute of the simple resource, so
+		// simple resources can be moved into it unchanged.
+		MovedFrom: []schema.Move{
+			{ResourceType: "tfcoremock_simple_resource"},
+		},
 	}
 }
 
@@ -6,7 +6,9 @@ package dynamic
 import (
 	"encoding/json"
 	"fmt"
+	"maps"
 	"os"
+	"slices"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -18,6 +20,7 @@ import (
 
 type Reader interface {
 	Read() (map[string]schema.Schema, error)
+	ReadFunctions() (map[string]schema.Function, error)
 }
 
 type FileReader struct {
@@ -28,7 +31,28 @@ type StringReader struct {
 	Data string
 }
 
+// functionsKey is the top level key that holds the provider functions in the
+// dynamic resources JSON. Every other key is a dynamic resource.
+const functionsKey = "functions"
+
+// definitions holds everything that can be read from the dynamic resources
+// JSON.
+type definitions struct {
+	Resources map[string]schema.Schema
+	Functions map[string]schema.Function
+}
+
 func (r FileReader) Read() (map[string]schema.Schema, error) {
+	definitions, err := r.read()
+	return definitions.Resources, err
+}
+
+func (r FileReader) ReadFunctions() (map[string]schema.Function, error) {
+	definitions, err := r.read()
+	return definitions.Functions, err
+}
+
+func (r FileReader) read() (definitions, error) {
 	schemaLoader := gojsonschema.NewStringLoader(jsonschema.DynamicResourcesJsonSchema)
 
 	data, err := os.ReadFile(r.File)
@@ -37,26 +61,19 @@ func (r FileReader) Read() (map[string]schema.Schema, error) {
 		//   file, but if the user has set the environment variable changing the
 		//   location maybe we should complain about it?
 		if os.IsNotExist(err) {
-			return nil, nil
+			return definitions{}, nil
 		}
-		return nil, errors.Wrap(err, "failed to read dynamic resources file")
+		return definitions{}, errors.Wrap(err, "failed to read dynamic resources file")
 	}
 
 	documentLoader := gojsonschema.NewStringLoader(string(data))
 	result, err := gojsonschema.Validate(schemaLoader, documentLoader)
 	if err != nil {
-		return nil, err
+		return definitions{}, err
 	}
 
 	if result.Valid() {
-		var dynamicResources map[string]schema.Schema
-		if len(data) > 0 {
-			if err := json.Unmarshal(data, &dynamicResources); err != nil {
-				return nil, errors.Wrap(err, "failed to unmarshal dynamic resources json")
-			}
-		}
-
-		return dynamicResources, nil
+		return parse(data)
 	}
 
 	var errs []string
@@ -64,15 +81,91 @@ func (r FileReader) Read() (map[string]schema.Schema, error) {
 		errs = append(errs, err.String())
 	}
 
-	return nil, fmt.Errorf("failed json schema check: %s", strings.Join(errs, ", "))
+	return definitions{}, fmt.Errorf("failed json schema check: %s", strings.Join(errs, ", "))
 }
 
 func (r StringReader) Read() (map[string]schema.Schema, error) {
-	var dynamicResources map[string]schema.Schema
-	if len(r.Data) > 0 {
-		if err := json.Unmarshal([]byte(r.Data), &dynamicResources); err != nil {
-			return nil, err
+	definitions, err := parse([]byte(r.Data))
+	return definitions.Resources, err
+}
+
+func (r StringReader) ReadFunctions() (map[string]schema.Function, error) {
+	definitions, err := parse([]byte(r.Data))
+	return definitions.Functions, err
+}
+
+// parse splits the functions out of the dynamic resources JSON, and then
+// unmarshals and validates the functions and resources separately.
+func parse(data []byte) (definitions, error) {
+	if len(data) == 0 {
+		return definitions{}, nil
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return definitions{}, errors.Wrap(err, "failed to unmarshal dynamic resources json")
+	}
+
+	var out definitions
+	if functions, ok := raw[functionsKey]; ok {
+		if err := json.Unmarshal(functions, &out.Functions); err != nil {
+			return definitions{}, errors.Wrap(err, "failed to unmarshal dynamic functions json")
+		}
+		delete(raw, functionsKey)
+	}
+
+	out.Resources = make(map[string]schema.Schema, len(raw))
+	for name, resource := range raw {
+		var dynamicResource schema.Schema
+		if err := json.Unmarshal(resource, &dynamicResource); err != nil {
+			return definitions{}, errors.Wrap(err, "failed to unmarshal dynamic resources json")
+		}
+		out.Resources[name] = dynamicResource
+	}
+
+	if err := validateBehaviours(out.Resources); err != nil {
+		return definitions{}, err
+	}
+	if err := validateFunctions(out.Functions); err != nil {
+		return definitions{}, err
+	}
+	return out, nil
+}
+
+// validateBehaviours checks the behaviours of each dynamic resource, as the
+// JSON schema can only check their structure and not, for example, whether
+// their patterns compile.
+func validateBehaviours(dynamicResources map[string]schema.Schema) error {
+	for _, name := range slices.Sorted(maps.Keys(dynamicResources)) {
+		if err := dynamicResources[name].Behaviours.Validate(); err != nil {
+			return errors.Wrapf(err, "invalid behaviours for %s", name)
+		}
+	}
+	return nil
+}
+
+// reservedFunctionNames are the names of the static functions the provider
+// always supplies. Functions in the dynamic resources file share the same
+// namespace, so they can't reuse these names.
+var reservedFunctionNames = []string{
+	"echo",
+	"fail",
+	"sensitive_echo",
+	"unknown_passthrough",
+	"variadic_count",
+}
+
+// validateFunctions checks the signature and results of each function agree
+// with each other, which the JSON schema cannot check, and that no function
+// reuses the name of a static function.
+func validateFunctions(functions map[string]schema.Function) error {
+	for _, name := range slices.Sorted(maps.Keys(functions)) {
+		if slices.Contains(reservedFunctionNames, name) {
+			return errors.Errorf("invalid function %s: the name is reserved for a static function supplied by the provider", name)
+		}
+		if err := functions[name].Validate(); err != nil {
+			return errors.Wrapf(err, "invalid function %s", name)
 		}
 	}
-	return dynamicResources, nil
+	return nil
 }
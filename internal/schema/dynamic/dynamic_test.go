@@ -0,0 +1,47 @@
+// Copyright IBM Corp. 2022, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package dynamic
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStringReader_ReadFunctions(t *testing.T) {
+	testCases := []struct {
+		TestCase string
+		Data     string
+		Error    string
+	}{
+		{
+			TestCase: "valid",
+			Data:     `{"functions": {"region_name": {"parameters": [], "return": {"type": "string"}, "result": {"string": "us-east-1"}}}}`,
+		},
+		{
+			TestCase: "reserved_name",
+			Data:     `{"functions": {"echo": {"parameters": [], "return": {"type": "string"}, "result": {"string": "hello"}}}}`,
+			Error:    "invalid function echo: the name is reserved for a static function supplied by the provider",
+		},
+		{
+			TestCase: "invalid_function",
+			Data:     `{"functions": {"region_name": {"parameters": [], "return": {"type": "string"}}}}`,
+			Error:    "invalid function region_name",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.TestCase, func(t *testing.T) {
+			_, err := StringReader{Data: testCase.Data}.ReadFunctions()
+			if len(testCase.Error) == 0 {
+				if err != nil {
+					t.Fatalf("expected no error but found %v", err)
+				}
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), testCase.Error) {
+				t.Fatalf("expected error containing %q but found %v", testCase.Error, err)
+			}
+		})
+	}
+}
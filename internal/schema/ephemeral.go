@@ -0,0 +1,283 @@
+// Copyright IBM Corp. 2022, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package schema
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var (
+	ephemeralResources = &AttributeTypes[schema.Attribute]{}
+)
+
+func init() {
+	ephemeralResources.asBoolean = asEphemeralBool
+	ephemeralResources.asFloat = asEphemeralFloat
+	ephemeralResources.asInteger = asEphemeralInteger
+	ephemeralResources.asNumber = asEphemeralNumber
+	ephemeralResources.asString = asEphemeralString
+	ephemeralResources.asList = asEphemeralList
+	ephemeralResources.asNestedList = asEphemeralNestedList
+	ephemeralResources.asMap = asEphemeralMap
+	ephemeralResources.asNestedMap = asEphemeralNestedMap
+	ephemeralResources.asSet = asEphemeralSet
+	ephemeralResources.asNestedSet = asEphemeralNestedSet
+	ephemeralResources.asObject = asEphemeralObject
+	ephemeralResources.asNestedObject = asEphemeralNestedObject
+}
+
+func asEphemeralBool(attribute Attribute) (*schema.Attribute, error) {
+	tfAttribute := schema.BoolAttribute{
+		Description:         attribute.Description,
+		MarkdownDescription: attribute.MarkdownDescription,
+		Optional:            attribute.Optional,
+		Required:            attribute.Required,
+		Computed:            attribute.Computed,
+		Sensitive:           attribute.Sensitive,
+	}
+
+	var out schema.Attribute
+	out = tfAttribute
+	return &out, nil
+}
+
+func asEphemeralFloat(attribute Attribute) (*schema.Attribute, error) {
+	tfAttribute := schema.Float64Attribute{
+		Description:         attribute.Description,
+		MarkdownDescription: attribute.MarkdownDescription,
+		Optional:            attribute.Optional,
+		Required:            attribute.Required,
+		Computed:            attribute.Computed,
+		Sensitive:           attribute.Sensitive,
+		Validators:          validatorsAs[validator.Float64](attribute.Validators),
+	}
+
+	var out schema.Attribute
+	out = tfAttribute
+	return &out, nil
+}
+
+func asEphemeralInteger(attribute Attribute) (*schema.Attribute, error) {
+	tfAttribute := schema.Int64Attribute{
+		Description:         attribute.Description,
+		MarkdownDescription: attribute.MarkdownDescription,
+		Optional:            attribute.Optional,
+		Required:            attribute.Required,
+		Computed:            attribute.Computed,
+		Sensitive:           attribute.Sensitive,
+		Validators:          validatorsAs[validator.Int64](attribute.Validators),
+	}
+
+	var out schema.Attribute
+	out = tfAttribute
+	return &out, nil
+}
+
+func asEphemeralNumber(attribute Attribute) (*schema.Attribute, error) {
+	tfAttribute := schema.NumberAttribute{
+		Description:         attribute.Description,
+		MarkdownDescription: attribute.MarkdownDescription,
+		Optional:            attribute.Optional,
+		Required:            attribute.Required,
+		Computed:            attribute.Computed,
+		Sensitive:           attribute.Sensitive,
+		Validators:          validatorsAs[validator.Number](attribute.Validators),
+	}
+
+	var out schema.Attribute
+	out = tfAttribute
+	return &out, nil
+}
+
+func asEphemeralString(attribute Attribute) (*schema.Attribute, error) {
+	tfAttribute := schema.StringAttribute{
+		Description:         attribute.Description,
+		MarkdownDescription: attribute.MarkdownDescription,
+		Optional:            attribute.Optional,
+		Required:            attribute.Required,
+		Computed:            attribute.Computed,
+		Sensitive:           attribute.Sensitive,
+		Validators:          validatorsAs[validator.String](attribute.Validators),
+	}
+
+	var out schema.Attribute
+	out = tfAttribute
+	return &out, nil
+}
+
+func asEphemeralList(attribute Attribute) (*schema.Attribute, error) {
+	tfAttribute := schema.ListAttribute{
+		Description:         attribute.Description,
+		MarkdownDescription: attribute.MarkdownDescription,
+		Optional:            attribute.Optional,
+		Required:            attribute.Required,
+		Computed:            attribute.Computed,
+		Sensitive:           attribute.Sensitive,
+		Validators:          validatorsAs[validator.List](attribute.Validators),
+	}
+
+	elem, err := ToTerraformAttribute(*attribute.List, ephemeralResources)
+	if err != nil {
+		return nil, err
+	}
+	tfAttribute.ElementType = (*elem).GetType()
+
+	var out schema.Attribute
+	out = tfAttribute
+	return &out, nil
+}
+
+func asEphemeralNestedList(attribute Attribute) (*schema.Attribute, error) {
+	tfAttribute := schema.ListNestedAttribute{
+		Description:         attribute.Description,
+		MarkdownDescription: attribute.MarkdownDescription,
+		Optional:            attribute.Optional,
+		Required:            attribute.Required,
+		Computed:            attribute.Computed,
+		Sensitive:           attribute.Sensitive,
+		Validators:          validatorsAs[validator.List](attribute.Validators),
+	}
+
+	var err error
+	if tfAttribute.NestedObject.Attributes, err = attributesToTerraformEphemeralAttributes(attribute.List.Object); err != nil {
+		return nil, err
+	}
+
+	var out schema.Attribute
+	out = tfAttribute
+	return &out, nil
+}
+
+func asEphemeralMap(attribute Attribute) (*schema.Attribute, error) {
+	tfAttribute := schema.MapAttribute{
+		Description:         attribute.Description,
+		MarkdownDescription: attribute.MarkdownDescription,
+		Optional:            attribute.Optional,
+		Required:            attribute.Required,
+		Computed:            attribute.Computed,
+		Sensitive:           attribute.Sensitive,
+		Validators:          validatorsAs[validator.Map](attribute.Validators),
+	}
+
+	elem, err := ToTerraformAttribute(*attribute.Map, ephemeralResources)
+	if err != nil {
+		return nil, err
+	}
+	tfAttribute.ElementType = (*elem).GetType()
+
+	var out schema.Attribute
+	out = tfAttribute
+	return &out, nil
+}
+
+func asEphemeralNestedMap(attribute Attribute) (*schema.Attribute, error) {
+	tfAttribute := schema.MapNestedAttribute{
+		Description:         attribute.Description,
+		MarkdownDescription: attribute.MarkdownDescription,
+		Optional:            attribute.Optional,
+		Required:            attribute.Required,
+		Computed:            attribute.Computed,
+		Sensitive:           attribute.Sensitive,
+		Validators:          validatorsAs[validator.Map](attribute.Validators),
+	}
+
+	var err error
+	if tfAttribute.NestedObject.Attributes, err = attributesToTerraformEphemeralAttributes(attribute.Map.Object); err != nil {
+		return nil, err
+	}
+
+	var out schema.Attribute
+	out = tfAttribute
+	return &out, nil
+}
+
+func asEphemeralSet(attribute Attribute) (*schema.Attribute, error) {
+	tfAttribute := schema.SetAttribute{
+		Description:         attribute.Description,
+		MarkdownDescription: attribute.MarkdownDescription,
+		Optional:            attribute.Optional,
+		Required:            attribute.Required,
+		Computed:            attribute.Computed,
+		Sensitive:           attribute.Sensitive,
+		Validators:          validatorsAs[validator.Set](attribute.Validators),
+	}
+
+	elem, err := ToTerraformAttribute(*attribute.Set, ephemeralResources)
+	if err != nil {
+		return nil, err
+	}
+	tfAttribute.ElementType = (*elem).GetType()
+
+	var out schema.Attribute
+	out = tfAttribute
+	return &out, nil
+}
+
+func asEphemeralNestedSet(attribute Attribute) (*schema.Attribute, error) {
+	tfAttribute := schema.SetNestedAttribute{
+		Description:         attribute.Description,
+		MarkdownDescription: attribute.MarkdownDescription,
+		Optional:            attribute.Optional,
+		Required:            attribute.Required,
+		Computed:            attribute.Computed,
+		Sensitive:           attribute.Sensitive,
+		Validators:          validatorsAs[validator.Set](attribute.Validators),
+	}
+
+	var err error
+	if tfAttribute.NestedObject.Attributes, err = attributesToTerraformEphemeralAttributes(attribute.Set.Object); err != nil {
+		return nil, err
+	}
+
+	var out schema.Attribute
+	out = tfAttribute
+	return &out, nil
+}
+
+func asEphemeralObject(attribute Attribute) (*schema.Attribute, error) {
+	tfAttribute := schema.ObjectAttribute{
+		Description:         attribute.Description,
+		MarkdownDescription: attribute.MarkdownDescription,
+		Optional:            attribute.Optional,
+		Required:            attribute.Required,
+		Computed:            attribute.Computed,
+		Sensitive:           attribute.Sensitive,
+	}
+
+	types := make(map[string]attr.Type)
+	attributes, err := attributesToTerraformEphemeralAttributes(attribute.Object)
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range attributes {
+		types[key] = value.GetType()
+	}
+	tfAttribute.AttributeTypes = types
+
+	var out schema.Attribute
+	out = tfAttribute
+	return &out, nil
+}
+
+func asEphemeralNestedObject(attribute Attribute) (*schema.Attribute, error) {
+	tfAttribute := schema.SingleNestedAttribute{
+		Description:         attribute.Description,
+		MarkdownDescription: attribute.MarkdownDescription,
+		Optional:            attribute.Optional,
+		Required:            attribute.Required,
+		Computed:            attribute.Computed,
+		Sensitive:           attribute.Sensitive,
+	}
+
+	var err error
+	if tfAttribute.Attributes, err = attributesToTerraformEphemeralAttributes(attribute.Object); err != nil {
+		return nil, err
+	}
+
+	var out schema.Attribute
+	out = tfAttribute
+	return &out, nil
+}
@@ -0,0 +1,296 @@
+// Copyright IBM Corp. 2022, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package schema
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/hashicorp/terraform-provider-tfcoremock/internal/data"
+)
+
+// Function defines an internal representation of a provider function, which
+// Terraform calls as `provider::tfcoremock::<name>`.
+//
+// It is designed to be read dynamically from a JSON object, allowing functions
+// with arbitrary signatures to be defined by the user of the provider.
+//
+// If Echo is set, a call returns the argument for the named parameter.
+// Otherwise, it returns the result of the first entry in Results whose
+// arguments match the call, falling back to the fixed Result.
+type Function struct {
+	Parameters        []Parameter  `json:"parameters"`
+	VariadicParameter *Parameter   `json:"variadic_parameter,omitempty"`
+	Return            FunctionType `json:"return"`
+
+	Result  *data.Value      `json:"result,omitempty"`
+	Results []FunctionResult `json:"results,omitempty"`
+	Echo    string           `json:"echo,omitempty"`
+}
+
+// FunctionType describes the type of a function parameter or return value,
+// using the same vocabulary as attributes.
+type FunctionType struct {
+	Type Type `json:"type"`
+
+	List   *FunctionType           `json:"list,omitempty"`
+	Map    *FunctionType           `json:"map,omitempty"`
+	Object map[string]FunctionType `json:"object,omitempty"`
+	Set    *FunctionType           `json:"set,omitempty"`
+}
+
+// Parameter describes a single parameter of a function.
+type Parameter struct {
+	FunctionType
+
+	Name string `json:"name"`
+
+	// AllowNull and AllowUnknown let null and unknown values be passed to the
+	// parameter. If any argument is unknown, the result of the call is unknown.
+	AllowNull    bool `json:"allow_null"`
+	AllowUnknown bool `json:"allow_unknown"`
+}
+
+// FunctionResult is the result a function returns when it is called with
+// exactly the given arguments. Arguments for the variadic parameter follow the
+// arguments for the other parameters.
+type FunctionResult struct {
+	Arguments []data.Value `json:"arguments"`
+	Result    data.Value   `json:"result"`
+}
+
+// Evaluate returns the result of calling the function with the given
+// arguments, which include any variadic arguments after the arguments for the
+// other parameters.
+func (f Function) Evaluate(arguments []data.Value) (data.Value, error) {
+	if len(f.Echo) > 0 {
+		for ix, parameter := range f.Parameters {
+			if parameter.Name == f.Echo {
+				return arguments[ix], nil
+			}
+		}
+		return data.Value{}, fmt.Errorf("echoed parameter %s does not exist", f.Echo)
+	}
+
+	for _, result := range f.Results {
+		if slices.EqualFunc(result.Arguments, arguments, data.Value.Equal) {
+			return result.Result, nil
+		}
+	}
+
+	if f.Result != nil {
+		return *f.Result, nil
+	}
+	return data.Value{}, errors.New("no result matches the arguments")
+}
+
+// ToTerraformFunctionDefinition converts our representation of a Function into
+// a Terraform SDK function definition.
+func (f Function) ToTerraformFunctionDefinition() (function.Definition, error) {
+	var out function.Definition
+	if err := f.Validate(); err != nil {
+		return out, err
+	}
+
+	for _, parameter := range f.Parameters {
+		tfParameter, err := parameter.toTerraformParameter()
+		if err != nil {
+			return out, err
+		}
+		out.Parameters = append(out.Parameters, tfParameter)
+	}
+
+	if f.VariadicParameter != nil {
+		tfParameter, err := f.VariadicParameter.toTerraformParameter()
+		if err != nil {
+			return out, err
+		}
+		out.VariadicParameter = tfParameter
+	}
+
+	var err error
+	if out.Return, err = f.Return.toTerraformReturn(); err != nil {
+		return out, err
+	}
+	return out, nil
+}
+
+// Validate checks the parameters, return type, and results of the function are
+// consistent with each other.
+func (f Function) Validate() error {
+	returnType, err := f.Return.ToTerraformType()
+	if err != nil {
+		return fmt.Errorf("return: %w", err)
+	}
+
+	parameters := slices.Clone(f.Parameters)
+	if f.VariadicParameter != nil {
+		parameters = append(parameters, *f.VariadicParameter)
+	}
+
+	names := make(map[string]bool)
+	for ix, parameter := range parameters {
+		if len(parameter.Name) == 0 {
+			return fmt.Errorf("parameters[%d]: missing name", ix)
+		}
+		if names[parameter.Name] {
+			return fmt.Errorf("parameters[%d]: parameter %s is declared more than once", ix, parameter.Name)
+		}
+		names[parameter.Name] = true
+
+		if _, err := parameter.ToTerraformType(); err != nil {
+			return fmt.Errorf("parameters[%d]: %w", ix, err)
+		}
+	}
+
+	if len(f.Echo) > 0 {
+		if f.Result != nil || len(f.Results) > 0 {
+			return errors.New("echo cannot be used with result or results")
+		}
+
+		ix := slices.IndexFunc(f.Parameters, func(parameter Parameter) bool {
+			return parameter.Name == f.Echo
+		})
+		if ix < 0 {
+			return fmt.Errorf("echo: %s is not a parameter, or is the variadic parameter", f.Echo)
+		}
+
+		parameterType, _ := f.Parameters[ix].ToTerraformType()
+		if !parameterType.Equal(returnType) {
+			return fmt.Errorf("echo: parameter %s does not match the return type", f.Echo)
+		}
+		return nil
+	}
+
+	if f.Result == nil && len(f.Results) == 0 {
+		return errors.New("one of result, results, or echo must be set")
+	}
+
+	ctx := context.Background()
+	if f.Result != nil {
+		if _, err := data.ToTerraform5Value(*f.Result, returnType.TerraformType(ctx)); err != nil {
+			return fmt.Errorf("result: %w", err)
+		}
+	}
+
+	for ix, result := range f.Results {
+		if len(result.Arguments) < len(f.Parameters) || (f.VariadicParameter == nil && len(result.Arguments) > len(f.Parameters)) {
+			return fmt.Errorf("results[%d]: wrong number of arguments", ix)
+		}
+		if _, err := data.ToTerraform5Value(result.Result, returnType.TerraformType(ctx)); err != nil {
+			return fmt.Errorf("results[%d]: %w", ix, err)
+		}
+	}
+	return nil
+}
+
+// ToTerraformType converts our representation of a type into the equivalent
+// Terraform SDK type.
+func (t FunctionType) ToTerraformType() (attr.Type, error) {
+	switch t.Type {
+	case Boolean:
+		return types.BoolType, nil
+	case Float:
+		return types.Float64Type, nil
+	case Integer:
+		return types.Int64Type, nil
+	case Number:
+		return types.NumberType, nil
+	case String:
+		return types.StringType, nil
+	case List:
+		elem, err := t.List.toTerraformElementType(List)
+		return types.ListType{ElemType: elem}, err
+	case Map:
+		elem, err := t.Map.toTerraformElementType(Map)
+		return types.MapType{ElemType: elem}, err
+	case Set:
+		elem, err := t.Set.toTerraformElementType(Set)
+		return types.SetType{ElemType: elem}, err
+	case Object:
+		attributes := make(map[string]attr.Type, len(t.Object))
+		for _, name := range slices.Sorted(maps.Keys(t.Object)) {
+			attribute, err := t.Object[name].ToTerraformType()
+			if err != nil {
+				return nil, err
+			}
+			attributes[name] = attribute
+		}
+		return types.ObjectType{AttrTypes: attributes}, nil
+	case "":
+		return nil, errors.New("missing type")
+	default:
+		return nil, fmt.Errorf("unrecognized type '%s'", t.Type)
+	}
+}
+
+func (t *FunctionType) toTerraformElementType(parent Type) (attr.Type, error) {
+	if t == nil {
+		return nil, fmt.Errorf("missing element type for %s", parent)
+	}
+	return t.ToTerraformType()
+}
+
+func (p Parameter) toTerraformParameter() (function.Parameter, error) {
+	t, err := p.ToTerraformType()
+	if err != nil {
+		return nil, err
+	}
+
+	switch p.Type {
+	case Boolean:
+		return function.BoolParameter{Name: p.Name, AllowNullValue: p.AllowNull, AllowUnknownValues: p.AllowUnknown}, nil
+	case Float:
+		return function.Float64Parameter{Name: p.Name, AllowNullValue: p.AllowNull, AllowUnknownValues: p.AllowUnknown}, nil
+	case Integer:
+		return function.Int64Parameter{Name: p.Name, AllowNullValue: p.AllowNull, AllowUnknownValues: p.AllowUnknown}, nil
+	case Number:
+		return function.NumberParameter{Name: p.Name, AllowNullValue: p.AllowNull, AllowUnknownValues: p.AllowUnknown}, nil
+	case String:
+		return function.StringParameter{Name: p.Name, AllowNullValue: p.AllowNull, AllowUnknownValues: p.AllowUnknown}, nil
+	case List:
+		return function.ListParameter{Name: p.Name, ElementType: t.(types.ListType).ElemType, AllowNullValue: p.AllowNull, AllowUnknownValues: p.AllowUnknown}, nil
+	case Map:
+		return function.MapParameter{Name: p.Name, ElementType: t.(types.MapType).ElemType, AllowNullValue: p.AllowNull, AllowUnknownValues: p.AllowUnknown}, nil
+	case Set:
+		return function.SetParameter{Name: p.Name, ElementType: t.(types.SetType).ElemType, AllowNullValue: p.AllowNull, AllowUnknownValues: p.AllowUnknown}, nil
+	default:
+		return function.ObjectParameter{Name: p.Name, AttributeTypes: t.(types.ObjectType).AttrTypes, AllowNullValue: p.AllowNull, AllowUnknownValues: p.AllowUnknown}, nil
+	}
+}
+
+func (t FunctionType) toTerraformReturn() (function.Return, error) {
+	tfType, err := t.ToTerraformType()
+	if err != nil {
+		return nil, err
+	}
+
+	switch t.Type {
+	case Boolean:
+		return function.BoolReturn{}, nil
+	case Float:
+		return function.Float64Return{}, nil
+	case Integer:
+		return function.Int64Return{}, nil
+	case Number:
+		return function.NumberReturn{}, nil
+	case String:
+		return function.StringReturn{}, nil
+	case List:
+		return function.ListReturn{ElementType: tfType.(types.ListType).ElemType}, nil
+	case Map:
+		return function.MapReturn{ElementType: tfType.(types.MapType).ElemType}, nil
+	case Set:
+		return function.SetReturn{ElementType: tfType.(types.SetType).ElemType}, nil
+	default:
+		return function.ObjectReturn{AttributeTypes: tfType.(types.ObjectType).AttrTypes}, nil
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright IBM Corp. 2022, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package schema
+
+import (
+	"errors"
+	"fmt"
+)
+
+type GeneratorType string
+
+const (
+	UUIDGenerator         GeneratorType = "uuid"
+	TimestampGenerator    GeneratorType = "timestamp"
+	SequenceGenerator     GeneratorType = "sequence"
+	RandomStringGenerator GeneratorType = "random_string"
+	RandomIntGenerator    GeneratorType = "random_int"
+)
+
+type RegenerateOn string
+
+const (
+	RegenerateOnCreate RegenerateOn = "create"
+	RegenerateOnUpdate RegenerateOn = "update"
+)
+
+// DefaultRandomStringLength is the length of the strings made by random_string
+// generators that don't specify a length.
+const DefaultRandomStringLength = 16
+
+// Generator describes how the provider should create the value for a computed
+// attribute, instead of returning a fixed value.
+type Generator struct {
+	Type GeneratorType `json:"type"`
+
+	// RegenerateOn controls when the value is replaced. Values are always
+	// generated when the resource is created, and are also generated again
+	// on every update if this is set to update.
+	RegenerateOn RegenerateOn `json:"regenerate_on,omitempty"`
+
+	// Seed makes the uuid, random_string and random_int generators
+	// deterministic. The nth value generated for an attribute of a given
+	// resource is always the same for the same seed.
+	Seed *int64 `json:"seed,omitempty"`
+
+	// Length is the number of characters made by random_string generators.
+	Length int64 `json:"length,omitempty"`
+
+	// Min and Max are the inclusive bounds of random_int generators. They
+	// default to 0 and 2147483647.
+	Min *int64 `json:"min,omitempty"`
+	Max *int64 `json:"max,omitempty"`
+}
+
+// RegeneratesOnUpdate returns true if the generator makes a new value every
+// time the resource is updated.
+func (g Generator) RegeneratesOnUpdate() bool {
+	return g.RegenerateOn == RegenerateOnUpdate
+}
+
+// Validate checks the generator is supported and can make values for an
+// attribute of the given type.
+func (g Generator) Validate(attributeType Type) error {
+	var types []Type
+	switch g.Type {
+	case UUIDGenerator, TimestampGenerator, RandomStringGenerator:
+		types = []Type{String}
+	case SequenceGenerator, RandomIntGenerator:
+		types = []Type{Integer, Number, String}
+	case "":
+		return errors.New("missing generator type")
+	default:
+		return fmt.Errorf("unrecognized generator type '%s'", g.Type)
+	}
+
+	supported := false
+	for _, t := range types {
+		supported = supported || t == attributeType
+	}
+	if !supported {
+		return fmt.Errorf("%s generators can't make values for %s attributes", g.Type, attributeType)
+	}
+
+	switch g.RegenerateOn {
+	case "", RegenerateOnCreate, RegenerateOnUpdate:
+	default:
+		return fmt.Errorf("unrecognized regenerate_on value '%s', expected create or update", g.RegenerateOn)
+	}
+
+	if g.Seed != nil && g.Type != UUIDGenerator && g.Type != RandomStringGenerator && g.Type != RandomIntGenerator {
+		return fmt.Errorf("%s generators don't accept a seed", g.Type)
+	}
+
+	if g.Length != 0 && g.Type != RandomStringGenerator {
+		return fmt.Errorf("%s generators don't accept a length", g.Type)
+	}
+	if g.Length < 0 {
+		return errors.New("length cannot be negative")
+	}
+
+	if (g.Min != nil || g.Max != nil) && g.Type != RandomIntGenerator {
+		return fmt.Errorf("%s generators don't accept a min or max", g.Type)
+	}
+	if min, max := g.Bounds(); min > max {
+		return errors.New("min cannot be greater than max")
+	}
+
+	return nil
+}
+
+// Bounds returns the inclusive bounds of a random_int generator, applying the
+// defaults for any that aren't set.
+func (g Generator) Bounds() (int64, int64) {
+	min, max := int64(0), int64(2147483647)
+	if g.Min != nil {
+		min = *g.Min
+	}
+	if g.Max != nil {
+		max = *g.Max
+	}
+	return min, max
+}
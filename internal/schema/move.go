@@ -0,0 +1,69 @@
+// Copyright IBM Corp. 2022, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package schema
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// Move describes another resource type that resources can be moved from with a
+// `moved` block.
+type Move struct {
+	ResourceType string `json:"resource_type"`
+
+	// Attributes maps the names of top level attributes and blocks in the
+	// source resource to their names in this resource. Anything that isn't
+	// mapped keeps its name, and anything this resource doesn't have is
+	// dropped.
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+// Apply renames the attributes in the state of a source resource, which is in
+// the same JSON format Terraform uses for its state files. Every attribute is
+// renamed at once, so attributes can swap names.
+func (move Move) Apply(state map[string]any) {
+	moved := make(map[string]any, len(move.Attributes))
+	for from, to := range move.Attributes {
+		if value, ok := state[from]; ok {
+			moved[to] = value
+			delete(state, from)
+		}
+	}
+	maps.Copy(state, moved)
+}
+
+// validateMoves checks each move names a different resource type, and only
+// maps attributes onto attributes and blocks this resource has.
+func (schema Schema) validateMoves() error {
+	seen := make(map[string]bool)
+	for ix, move := range schema.MovedFrom {
+		if len(move.ResourceType) == 0 {
+			return fmt.Errorf("moved_from[%d]: missing resource_type", ix)
+		}
+		if seen[move.ResourceType] {
+			return fmt.Errorf("moved_from[%d]: moves from %s are declared more than once", ix, move.ResourceType)
+		}
+		seen[move.ResourceType] = true
+
+		targets := make(map[string]bool)
+		for _, from := range slices.Sorted(maps.Keys(move.Attributes)) {
+			to := move.Attributes[from]
+			if from == "id" || to == "id" {
+				return fmt.Errorf("moved_from[%d]: moves cannot change the id attribute", ix)
+			}
+			if _, ok := schema.Attributes[to]; !ok {
+				if _, ok := schema.Blocks[to]; !ok {
+					return fmt.Errorf("moved_from[%d]: %s is mapped to missing attribute %s", ix, from, to)
+				}
+			}
+			if targets[to] {
+				return fmt.Errorf("moved_from[%d]: more than one attribute is mapped to %s", ix, to)
+			}
+			targets[to] = true
+		}
+	}
+	return nil
+}
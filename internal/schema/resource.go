@@ -15,6 +15,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 )
 
 var (
@@ -45,6 +46,7 @@ func asResourceBool(attribute Attribute) (*schema.Attribute, error) {
 		Required:            attribute.Required,
 		Computed:            attribute.Computed,
 		Sensitive:           attribute.Sensitive,
+		WriteOnly:           attribute.WriteOnly,
 	}
 
 	if attribute.Computed {
@@ -68,6 +70,8 @@ func asResourceFloat(attribute Attribute) (*schema.Attribute, error) {
 		Required:            attribute.Required,
 		Computed:            attribute.Computed,
 		Sensitive:           attribute.Sensitive,
+		WriteOnly:           attribute.WriteOnly,
+		Validators:          validatorsAs[validator.Float64](attribute.Validators),
 	}
 
 	if attribute.Computed {
@@ -91,6 +95,8 @@ func asResourceInteger(attribute Attribute) (*schema.Attribute, error) {
 		Required:            attribute.Required,
 		Computed:            attribute.Computed,
 		Sensitive:           attribute.Sensitive,
+		WriteOnly:           attribute.WriteOnly,
+		Validators:          validatorsAs[validator.Int64](attribute.Validators),
 	}
 
 	if attribute.Computed {
@@ -114,6 +120,8 @@ func asResourceNumber(attribute Attribute) (*schema.Attribute, error) {
 		Required:            attribute.Required,
 		Computed:            attribute.Computed,
 		Sensitive:           attribute.Sensitive,
+		WriteOnly:           attribute.WriteOnly,
+		Validators:          validatorsAs[validator.Number](attribute.Validators),
 	}
 
 	if attribute.Computed {
@@ -137,6 +145,8 @@ func asResourceString(attribute Attribute) (*schema.Attribute, error) {
 		Required:            attribute.Required,
 		Computed:            attribute.Computed,
 		Sensitive:           attribute.Sensitive,
+		WriteOnly:           attribute.WriteOnly,
+		Validators:          validatorsAs[validator.String](attribute.Validators),
 	}
 
 	if attribute.Computed {
@@ -160,6 +170,8 @@ func asResourceList(attribute Attribute) (*schema.Attribute, error) {
 		Required:            attribute.Required,
 		Computed:            attribute.Computed,
 		Sensitive:           attribute.Sensitive,
+		WriteOnly:           attribute.WriteOnly,
+		Validators:          validatorsAs[validator.List](attribute.Validators),
 	}
 
 	elem, err := ToTerraformAttribute(*attribute.List, resources)
@@ -189,6 +201,8 @@ func asResourceNestedList(attribute Attribute) (*schema.Attribute, error) {
 		Required:            attribute.Required,
 		Computed:            attribute.Computed,
 		Sensitive:           attribute.Sensitive,
+		WriteOnly:           attribute.WriteOnly,
+		Validators:          validatorsAs[validator.List](attribute.Validators),
 	}
 
 	var err error
@@ -217,6 +231,8 @@ func asResourceMap(attribute Attribute) (*schema.Attribute, error) {
 		Required:            attribute.Required,
 		Computed:            attribute.Computed,
 		Sensitive:           attribute.Sensitive,
+		WriteOnly:           attribute.WriteOnly,
+		Validators:          validatorsAs[validator.Map](attribute.Validators),
 	}
 
 	elem, err := ToTerraformAttribute(*attribute.Map, resources)
@@ -246,6 +262,8 @@ func asResourceNestedMap(attribute Attribute) (*schema.Attribute, error) {
 		Required:            attribute.Required,
 		Computed:            attribute.Computed,
 		Sensitive:           attribute.Sensitive,
+		WriteOnly:           attribute.WriteOnly,
+		Validators:          validatorsAs[validator.Map](attribute.Validators),
 	}
 
 	var err error
@@ -274,6 +292,7 @@ func asResourceSet(attribute Attribute) (*schema.Attribute, error) {
 		Required:            attribute.Required,
 		Computed:            attribute.Computed,
 		Sensitive:           attribute.Sensitive,
+		Validators:          validatorsAs[validator.Set](attribute.Validators),
 	}
 
 	elem, err := ToTerraformAttribute(*attribute.Set, resources)
@@ -303,6 +322,7 @@ func asResourceNestedSet(attribute Attribute) (*schema.Attribute, error) {
 		Required:            attribute.Required,
 		Computed:            attribute.Computed,
 		Sensitive:           attribute.Sensitive,
+		Validators:          validatorsAs[validator.Set](attribute.Validators),
 	}
 
 	var err error
@@ -331,6 +351,7 @@ func asResourceObject(attribute Attribute) (*schema.Attribute, error) {
 		Required:            attribute.Required,
 		Computed:            attribute.Computed,
 		Sensitive:           attribute.Sensitive,
+		WriteOnly:           attribute.WriteOnly,
 	}
 
 	types := make(map[string]attr.Type)
@@ -364,6 +385,7 @@ func asResourceNestedObject(attribute Attribute) (*schema.Attribute, error) {
 		Required:            attribute.Required,
 		Computed:            attribute.Computed,
 		Sensitive:           attribute.Sensitive,
+		WriteOnly:           attribute.WriteOnly,
 	}
 
 	var err error
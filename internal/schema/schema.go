@@ -5,12 +5,18 @@ package schema
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 
 	action_schema "github.com/hashicorp/terraform-plugin-framework/action/schema"
 	datasource_schema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	ephemeral_schema "github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
 	resource_schema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	resource_schema_planmodifier "github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	resource_schema_stringplanmodifier "github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/hashicorp/terraform-provider-tfcoremock/internal/behaviour"
 )
 
 // Schema defines an internal representation of a Terraform schema.
@@ -22,6 +28,21 @@ type Schema struct {
 	MarkdownDescription string               `json:"-"` // Dynamic resources don't need descriptions so hide them from the exposed JSON schema.
 	Attributes          map[string]Attribute `json:"attributes"`
 	Blocks              map[string]Block     `json:"blocks"`
+
+	// Version is the version of the schema, which Terraform records alongside
+	// the state of each resource. Upgrades describe how state recorded by
+	// earlier versions is upgraded to the current version.
+	Version  int64     `json:"version"`
+	Upgrades []Upgrade `json:"upgrades"`
+
+	// MovedFrom lists the other resource types that resources can be moved
+	// from using `moved` blocks.
+	MovedFrom []Move `json:"moved_from"`
+
+	// Behaviours holds failures, deferrals, delays, crashes, drifts and
+	// inconsistencies that only apply to this resource type. They are applied
+	// after any behaviours set in the provider configuration.
+	Behaviours behaviour.Behaviours `json:"behaviours"`
 }
 
 // AllAttributes returns the attributes for the dynamic schema, plus the
@@ -48,12 +69,19 @@ func (schema Schema) ToTerraformResourceSchema() (resource_schema.Schema, error)
 	out := resource_schema.Schema{
 		Description:         schema.Description,
 		MarkdownDescription: schema.MarkdownDescription,
+		Version:             schema.Version,
 	}
 
 	var err error
 	if err = schema.validateAttributes(); err != nil {
 		return out, err
 	}
+	if err = schema.validateUpgrades(); err != nil {
+		return out, err
+	}
+	if err = schema.validateMoves(); err != nil {
+		return out, err
+	}
 
 	if out.Attributes, err = attributesToTerraformResourceAttributes(schema.Attributes); err != nil {
 		return out, err
@@ -107,6 +135,63 @@ func (schema Schema) ToTerraformDataSourceSchema() (datasource_schema.Schema, er
 	return out, nil
 }
 
+// RenewAt is the name of the attribute that ephemeral resources use to decide
+// how often Terraform should renew them.
+const RenewAt = "renew_at"
+
+// HasRenewAt returns true if ephemeral resources built from this schema have
+// the `renew_at` attribute. It is added to every schema that doesn't already
+// have an attribute or block with the same name.
+func (schema Schema) HasRenewAt() bool {
+	if _, ok := schema.Attributes[RenewAt]; ok {
+		return false
+	}
+	if _, ok := schema.Blocks[RenewAt]; ok {
+		return false
+	}
+	return true
+}
+
+// ToTerraformEphemeralResourceSchema converts our representation of a Schema
+// into a Terraform SDK ephemeral resource schema. It automatically creates and
+// attaches a computed attribute called `id`, and an optional `renew_at`
+// attribute that controls how often the ephemeral resource is renewed.
+func (schema Schema) ToTerraformEphemeralResourceSchema() (ephemeral_schema.Schema, error) {
+	out := ephemeral_schema.Schema{
+		Description:         schema.Description,
+		MarkdownDescription: schema.MarkdownDescription,
+	}
+
+	var err error
+	if err = schema.validateAttributes(); err != nil {
+		return out, err
+	}
+
+	if out.Attributes, err = attributesToTerraformEphemeralAttributes(schema.Attributes); err != nil {
+		return out, err
+	}
+
+	out.Attributes["id"] = ephemeral_schema.StringAttribute{
+		Required: false,
+		Optional: true,
+		Computed: true,
+	}
+
+	if schema.HasRenewAt() {
+		out.Attributes[RenewAt] = ephemeral_schema.StringAttribute{
+			Description:         "How long Terraform should wait before renewing the ephemeral resource, for example `30s`. If unset, the ephemeral resource is never renewed.",
+			MarkdownDescription: "How long Terraform should wait before renewing the ephemeral resource, for example `30s`. If unset, the ephemeral resource is never renewed.",
+			Optional:            true,
+		}
+	}
+
+	if out.Blocks, err = blocksToTerraformEphemeralBlocks(schema.Blocks); err != nil {
+		return out, err
+	}
+
+	return out, nil
+}
+
 func (schema Schema) ToTerraformActionSchema() (action_schema.Schema, error) {
 	out := action_schema.Schema{
 		Description:         schema.Description,
@@ -129,9 +214,254 @@ func (schema Schema) ToTerraformActionSchema() (action_schema.Schema, error) {
 	return out, nil
 }
 
+// RequiresReplace returns true if a change to the value at the given path
+// would force the resource to be replaced, either because the root `id`
+// attribute changed or because the path is within an attribute that has been
+// marked with Replace.
+func (schema Schema) RequiresReplace(path *tftypes.AttributePath) bool {
+	if steps := path.Steps(); len(steps) > 0 && steps[0] == tftypes.AttributeName("id") {
+		return true
+	}
+
+	for _, attribute := range schema.attributesAlong(path) {
+		if attribute != nil && attribute.Replace {
+			return true
+		}
+	}
+	return false
+}
+
+// KnownAfterApply returns true if the value at the given path is an attribute
+// that has been marked with KnownAfterApply, or that has a generator making a
+// new value on every update.
+//
+// Only attributes reached by name can be known after apply, not the elements
+// of lists, maps, or sets.
+func (schema Schema) KnownAfterApply(path *tftypes.AttributePath) bool {
+	attribute := schema.AttributeAt(path)
+	return attribute != nil && (attribute.KnownAfterApply || (attribute.Generator != nil && attribute.Generator.RegeneratesOnUpdate()))
+}
+
+// WriteOnly returns true if the value at the given path is an attribute that
+// has been marked with WriteOnly.
+func (schema Schema) WriteOnly(path *tftypes.AttributePath) bool {
+	attribute := schema.AttributeAt(path)
+	return attribute != nil && attribute.WriteOnly
+}
+
+// AttributeAt returns the attribute at the given path, or nil if the path
+// doesn't end at an attribute reached by name.
+func (schema Schema) AttributeAt(path *tftypes.AttributePath) *Attribute {
+	steps := path.Steps()
+	if len(steps) == 0 {
+		return nil
+	}
+	if _, ok := steps[len(steps)-1].(tftypes.AttributeName); !ok {
+		return nil
+	}
+
+	attributes := schema.attributesAlong(path)
+	if len(attributes) != len(steps) {
+		// Then we couldn't follow the whole path through the schema.
+		return nil
+	}
+	return attributes[len(attributes)-1]
+}
+
+// attributesAlong steps through the schema following the given path, and
+// returns the attribute reached by each step. Steps through blocks have a nil
+// attribute, and the returned attributes stop early if the path leaves the
+// schema.
+func (schema Schema) attributesAlong(path *tftypes.AttributePath) []*Attribute {
+	attributes := schema.AllAttributes()
+	blocks := schema.Blocks
+
+	// current is the attribute we are within, it is nil if we are still
+	// stepping through blocks.
+	var current *Attribute
+
+	var along []*Attribute
+	for _, step := range path.Steps() {
+		switch step := step.(type) {
+		case tftypes.AttributeName:
+			name := string(step)
+
+			if current != nil {
+				next, ok := current.Object[name]
+				if !ok {
+					return along
+				}
+				current = &next
+			} else if attribute, ok := attributes[name]; ok {
+				current = &attribute
+			} else if block, ok := blocks[name]; ok {
+				attributes = block.Attributes
+				blocks = block.Blocks
+				along = append(along, nil)
+				continue
+			} else {
+				return along
+			}
+		case tftypes.ElementKeyInt, tftypes.ElementKeyString, tftypes.ElementKeyValue:
+			if current == nil {
+				// Then we're stepping into the elements of a block, and blocks
+				// don't have any metadata of their own.
+				along = append(along, nil)
+				continue
+			}
+
+			switch current.Type {
+			case List:
+				current = current.List
+			case Map:
+				current = current.Map
+			case Set:
+				current = current.Set
+			default:
+				return along
+			}
+
+			if current == nil {
+				return along
+			}
+		}
+
+		along = append(along, current)
+	}
+	return along
+}
+
 func (schema Schema) validateAttributes() error {
 	if _, ok := schema.Attributes["id"]; ok {
 		return errors.New("top level dynamic objects cannot define a value called `id` as the provider will generate an identifier for them")
 	}
+	if err := validateComputed(schema.Attributes, schema.Blocks); err != nil {
+		return err
+	}
+	if err := validateWriteOnly(schema.Attributes, schema.Blocks, false); err != nil {
+		return err
+	}
+	if err := validateValidators(schema.Attributes, schema.Blocks); err != nil {
+		return err
+	}
+	return schema.validateTemplates(schema.Attributes, schema.Blocks)
+}
+
+// validateTemplates checks that every reference in the templates of the given
+// attributes, and any nested attributes, refers to a primitive attribute that
+// doesn't have a template itself.
+func (schema Schema) validateTemplates(attributes map[string]Attribute, blocks map[string]Block) error {
+	for name, attribute := range attributes {
+		if err := schema.validateTemplate(attribute); err != nil {
+			return fmt.Errorf("attribute %s: %w", name, err)
+		}
+	}
+	for name, block := range blocks {
+		if err := schema.validateTemplates(block.Attributes, block.Blocks); err != nil {
+			return fmt.Errorf("block %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func (schema Schema) validateTemplate(attribute Attribute) error {
+	references, err := attribute.Template.References()
+	if err != nil {
+		return err
+	}
+
+	for _, reference := range references {
+		name := strings.Join(reference, ".")
+
+		referenced := schema.Referenced(reference)
+		if referenced == nil {
+			return fmt.Errorf("template refers to missing attribute %s", name)
+		}
+		if len(referenced.Template) > 0 {
+			return fmt.Errorf("template refers to %s, which has a template of its own", name)
+		}
+		switch referenced.Type {
+		case Boolean, Float, Integer, Number, String:
+		default:
+			return fmt.Errorf("template refers to %s, which is not a primitive attribute", name)
+		}
+	}
+
+	for _, nested := range []*Attribute{attribute.List, attribute.Map, attribute.Set} {
+		if nested != nil {
+			if err := schema.validateTemplate(*nested); err != nil {
+				return err
+			}
+		}
+	}
+	return schema.validateTemplates(attribute.Object, nil)
+}
+
+// Referenced returns the attribute a template reference refers to, by following
+// the names in the reference from the root of the schema through any nested
+// objects. It returns nil if the reference doesn't lead to an attribute.
+func (schema Schema) Referenced(reference []string) *Attribute {
+	attributes := schema.AllAttributes()
+
+	var current *Attribute
+	for _, name := range reference {
+		if current != nil {
+			if current.Type != Object {
+				return nil
+			}
+			attributes = current.Object
+		}
+
+		attribute, ok := attributes[name]
+		if !ok {
+			return nil
+		}
+		current = &attribute
+	}
+	return current
+}
+
+// validateComputed checks that only computed attributes have been marked as
+// known after apply or given a generator, as Terraform won't accept values for
+// attributes the provider can't set.
+func validateComputed(attributes map[string]Attribute, blocks map[string]Block) error {
+	for name, attribute := range attributes {
+		if err := attribute.validateComputed(); err != nil {
+			return fmt.Errorf("attribute %s: %w", name, err)
+		}
+	}
+	for name, block := range blocks {
+		if err := validateComputed(block.Attributes, block.Blocks); err != nil {
+			return fmt.Errorf("block %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func validateValidators(attributes map[string]Attribute, blocks map[string]Block) error {
+	for name, attribute := range attributes {
+		if err := attribute.validateValidators(false); err != nil {
+			return fmt.Errorf("attribute %s: %w", name, err)
+		}
+	}
+	for name, block := range blocks {
+		if err := validateValidators(block.Attributes, block.Blocks); err != nil {
+			return fmt.Errorf("block %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func validateWriteOnly(attributes map[string]Attribute, blocks map[string]Block, inSet bool) error {
+	for name, attribute := range attributes {
+		if err := attribute.validateWriteOnly(false, inSet); err != nil {
+			return fmt.Errorf("attribute %s: %w", name, err)
+		}
+	}
+	for name, block := range blocks {
+		if err := validateWriteOnly(block.Attributes, block.Blocks, inSet || block.Mode == NestingModeSet); err != nil {
+			return fmt.Errorf("block %s: %w", name, err)
+		}
+	}
 	return nil
 }
@@ -0,0 +1,1345 @@
+// Copyright IBM Corp. 2022, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package schema
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/hashicorp/terraform-provider-tfcoremock/internal/data"
+)
+
+func TestSchema_RequiresReplace(t *testing.T) {
+	schema := Schema{
+		Attributes: map[string]Attribute{
+			"replace": {
+				Type:    String,
+				Replace: true,
+			},
+			"update": {
+				Type: String,
+			},
+			"list": {
+				Type: List,
+				List: &Attribute{
+					Type: Object,
+					Object: map[string]Attribute{
+						"replace": {
+							Type:    String,
+							Replace: true,
+						},
+						"update": {
+							Type: String,
+						},
+					},
+				},
+			},
+		},
+		Blocks: map[string]Block{
+			"block": {
+				Attributes: map[string]Attribute{
+					"replace": {
+						Type:    String,
+						Replace: true,
+					},
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		TestCase string
+		Path     *tftypes.AttributePath
+		Expected bool
+	}{
+		{
+			TestCase: "id",
+			Path:     tftypes.NewAttributePath().WithAttributeName("id"),
+			Expected: true,
+		},
+		{
+			TestCase: "replace",
+			Path:     tftypes.NewAttributePath().WithAttributeName("replace"),
+			Expected: true,
+		},
+		{
+			TestCase: "update",
+			Path:     tftypes.NewAttributePath().WithAttributeName("update"),
+			Expected: false,
+		},
+		{
+			TestCase: "list",
+			Path:     tftypes.NewAttributePath().WithAttributeName("list"),
+			Expected: false,
+		},
+		{
+			TestCase: "nested_replace",
+			Path:     tftypes.NewAttributePath().WithAttributeName("list").WithElementKeyInt(0).WithAttributeName("replace"),
+			Expected: true,
+		},
+		{
+			TestCase: "nested_update",
+			Path:     tftypes.NewAttributePath().WithAttributeName("list").WithElementKeyInt(0).WithAttributeName("update"),
+			Expected: false,
+		},
+		{
+			TestCase: "block_replace",
+			Path:     tftypes.NewAttributePath().WithAttributeName("block").WithElementKeyInt(0).WithAttributeName("replace"),
+			Expected: true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.TestCase, func(t *testing.T) {
+			if actual := schema.RequiresReplace(testCase.Path); actual != testCase.Expected {
+				t.Fatalf("expected %t but found %t", testCase.Expected, actual)
+			}
+		})
+	}
+}
+
+func TestSchema_KnownAfterApply(t *testing.T) {
+	schema := Schema{
+		Attributes: map[string]Attribute{
+			"known": {
+				Type:     String,
+				Computed: true,
+			},
+			"unknown": {
+				Type:            String,
+				Computed:        true,
+				KnownAfterApply: true,
+			},
+			"map": {
+				Type: Map,
+				Map: &Attribute{
+					Type:            String,
+					Computed:        true,
+					KnownAfterApply: true,
+				},
+			},
+			"generated_on_create": {
+				Type:     String,
+				Computed: true,
+				Generator: &Generator{
+					Type: UUIDGenerator,
+				},
+			},
+			"generated_on_update": {
+				Type:     String,
+				Computed: true,
+				Generator: &Generator{
+					Type:         TimestampGenerator,
+					RegenerateOn: RegenerateOnUpdate,
+				},
+			},
+		},
+		Blocks: map[string]Block{
+			"block": {
+				Attributes: map[string]Attribute{
+					"unknown": {
+						Type:            String,
+						Computed:        true,
+						KnownAfterApply: true,
+					},
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		TestCase string
+		Path     *tftypes.AttributePath
+		Expected bool
+	}{
+		{
+			TestCase: "known",
+			Path:     tftypes.NewAttributePath().WithAttributeName("known"),
+			Expected: false,
+		},
+		{
+			TestCase: "unknown",
+			Path:     tftypes.NewAttributePath().WithAttributeName("unknown"),
+			Expected: true,
+		},
+		{
+			TestCase: "map_element",
+			Path:     tftypes.NewAttributePath().WithAttributeName("map").WithElementKeyString("key"),
+			Expected: false,
+		},
+		{
+			TestCase: "block_unknown",
+			Path:     tftypes.NewAttributePath().WithAttributeName("block").WithElementKeyInt(0).WithAttributeName("unknown"),
+			Expected: true,
+		},
+		{
+			TestCase: "generated_on_create",
+			Path:     tftypes.NewAttributePath().WithAttributeName("generated_on_create"),
+			Expected: false,
+		},
+		{
+			TestCase: "generated_on_update",
+			Path:     tftypes.NewAttributePath().WithAttributeName("generated_on_update"),
+			Expected: true,
+		},
+		{
+			TestCase: "missing",
+			Path:     tftypes.NewAttributePath().WithAttributeName("missing"),
+			Expected: false,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.TestCase, func(t *testing.T) {
+			if actual := schema.KnownAfterApply(testCase.Path); actual != testCase.Expected {
+				t.Fatalf("expected %t but found %t", testCase.Expected, actual)
+			}
+		})
+	}
+}
+
+func TestSchema_Generators(t *testing.T) {
+	seed := int64(0)
+	min, max := int64(10), int64(1)
+
+	testCases := []struct {
+		TestCase  string
+		Attribute Attribute
+		Error     string
+	}{
+		{
+			TestCase: "uuid",
+			Attribute: Attribute{
+				Type:      String,
+				Computed:  true,
+				Generator: &Generator{Type: UUIDGenerator, Seed: &seed},
+			},
+		},
+		{
+			TestCase: "sequence",
+			Attribute: Attribute{
+				Type:      Integer,
+				Computed:  true,
+				Generator: &Generator{Type: SequenceGenerator, RegenerateOn: RegenerateOnUpdate},
+			},
+		},
+		{
+			TestCase: "not_computed",
+			Attribute: Attribute{
+				Type:      String,
+				Optional:  true,
+				Generator: &Generator{Type: UUIDGenerator},
+			},
+			Error: "attribute generated: only computed attributes can have a generator",
+		},
+		{
+			TestCase: "wrong_type",
+			Attribute: Attribute{
+				Type:      Boolean,
+				Computed:  true,
+				Generator: &Generator{Type: TimestampGenerator},
+			},
+			Error: "attribute generated: invalid generator: timestamp generators can't make values for boolean attributes",
+		},
+		{
+			TestCase: "unknown_regenerate_on",
+			Attribute: Attribute{
+				Type:      String,
+				Computed:  true,
+				Generator: &Generator{Type: UUIDGenerator, RegenerateOn: "delete"},
+			},
+			Error: "attribute generated: invalid generator: unrecognized regenerate_on value 'delete', expected create or update",
+		},
+		{
+			TestCase: "seeded_timestamp",
+			Attribute: Attribute{
+				Type:      String,
+				Computed:  true,
+				Generator: &Generator{Type: TimestampGenerator, Seed: &seed},
+			},
+			Error: "attribute generated: invalid generator: timestamp generators don't accept a seed",
+		},
+		{
+			TestCase: "bad_bounds",
+			Attribute: Attribute{
+				Type:      Integer,
+				Computed:  true,
+				Generator: &Generator{Type: RandomIntGenerator, Min: &min, Max: &max},
+			},
+			Error: "attribute generated: invalid generator: min cannot be greater than max",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.TestCase, func(t *testing.T) {
+			schema := Schema{
+				Attributes: map[string]Attribute{
+					"generated": testCase.Attribute,
+				},
+			}
+
+			err := schema.validateAttributes()
+			if len(testCase.Error) == 0 {
+				if err != nil {
+					t.Fatalf("expected no error but found %v", err)
+				}
+				return
+			}
+
+			if err == nil || err.Error() != testCase.Error {
+				t.Fatalf("expected %q but found %v", testCase.Error, err)
+			}
+		})
+	}
+}
+
+func TestSchema_Templates(t *testing.T) {
+	testCases := []struct {
+		TestCase string
+		Template Template
+		Error    string
+	}{
+		{
+			TestCase: "literal",
+			Template: "hello",
+		},
+		{
+			TestCase: "references",
+			Template: "arn:mock:${name}/${object.name}/${id}",
+		},
+		{
+			TestCase: "escaped",
+			Template: "$${missing}",
+		},
+		{
+			TestCase: "missing",
+			Template: "${missing}",
+			Error:    "attribute templated: template refers to missing attribute missing",
+		},
+		{
+			TestCase: "nested_missing",
+			Template: "${name.missing}",
+			Error:    "attribute templated: template refers to missing attribute name.missing",
+		},
+		{
+			TestCase: "object",
+			Template: "${object}",
+			Error:    "attribute templated: template refers to object, which is not a primitive attribute",
+		},
+		{
+			TestCase: "templated",
+			Template: "${templated}",
+			Error:    "attribute templated: template refers to templated, which has a template of its own",
+		},
+		{
+			TestCase: "unterminated",
+			Template: "${name",
+			Error:    "attribute templated: invalid template: unterminated reference in template",
+		},
+		{
+			TestCase: "invalid",
+			Template: "${name[0]}",
+			Error:    "attribute templated: invalid template: invalid reference 'name[0]' in template",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.TestCase, func(t *testing.T) {
+			schema := Schema{
+				Attributes: map[string]Attribute{
+					"name": {
+						Type:     String,
+						Required: true,
+					},
+					"object": {
+						Type:     Object,
+						Optional: true,
+						Object: map[string]Attribute{
+							"name": {
+								Type:     String,
+								Optional: true,
+							},
+						},
+					},
+					"templated": {
+						Type:     String,
+						Computed: true,
+						Template: testCase.Template,
+					},
+				},
+			}
+
+			err := schema.validateAttributes()
+			if len(testCase.Error) == 0 {
+				if err != nil {
+					t.Fatalf("expected no error but found %v", err)
+				}
+				return
+			}
+
+			if err == nil || err.Error() != testCase.Error {
+				t.Fatalf("expected %q but found %v", testCase.Error, err)
+			}
+		})
+	}
+}
+
+func TestTemplate_Render(t *testing.T) {
+	values := map[string]string{
+		"name":        "hello",
+		"object.name": "world",
+	}
+
+	testCases := []struct {
+		TestCase string
+		Template Template
+		Expected string
+		Known    bool
+	}{
+		{
+			TestCase: "literal",
+			Template: "hello",
+			Expected: "hello",
+			Known:    true,
+		},
+		{
+			TestCase: "references",
+			Template: "${name}.${ object.name }",
+			Expected: "hello.world",
+			Known:    true,
+		},
+		{
+			TestCase: "escaped",
+			Template: "$${name}",
+			Expected: "${name}",
+			Known:    true,
+		},
+		{
+			TestCase: "unknown",
+			Template: "${name}.${unknown}",
+			Known:    false,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.TestCase, func(t *testing.T) {
+			actual, known, err := testCase.Template.Render(func(reference []string) (string, bool, error) {
+				value, ok := values[strings.Join(reference, ".")]
+				return value, ok, nil
+			})
+			if err != nil {
+				t.Fatalf("expected no error but found %v", err)
+			}
+			if known != testCase.Known {
+				t.Fatalf("expected known to be %t but found %t", testCase.Known, known)
+			}
+			if actual != testCase.Expected {
+				t.Fatalf("expected %q but found %q", testCase.Expected, actual)
+			}
+		})
+	}
+}
+
+func TestSchema_Upgrades(t *testing.T) {
+	value := "default"
+
+	testCases := []struct {
+		TestCase string
+		Upgrades []Upgrade
+		Error    string
+	}{
+		{
+			TestCase: "valid",
+			Upgrades: []Upgrade{
+				{Version: 0, Steps: []UpgradeStep{{Type: RenameUpgrade, Attribute: "name", To: "title"}}},
+				{Version: 1, Steps: []UpgradeStep{{Type: WrapUpgrade, Attributes: []string{"host", "port"}, To: "endpoint"}}},
+			},
+		},
+		{
+			TestCase: "current_version",
+			Upgrades: []Upgrade{{Version: 2}},
+			Error:    "upgrades[0]: version 2 is not before the current version 2",
+		},
+		{
+			TestCase: "duplicate_version",
+			Upgrades: []Upgrade{{Version: 1}, {Version: 1}},
+			Error:    "upgrades[1]: version 1 is upgraded more than once",
+		},
+		{
+			TestCase: "missing_to",
+			Upgrades: []Upgrade{{Version: 0, Steps: []UpgradeStep{{Type: RenameUpgrade, Attribute: "name"}}}},
+			Error:    "upgrades[0].steps[0]: rename steps require a new name in to, without any dots",
+		},
+		{
+			TestCase: "complex_conversion",
+			Upgrades: []Upgrade{{Version: 0, Steps: []UpgradeStep{{Type: ConvertUpgrade, Attribute: "name", To: "list"}}}},
+			Error:    "upgrades[0].steps[0]: convert steps can only convert to primitive types, not 'list'",
+		},
+		{
+			TestCase: "missing_default",
+			Upgrades: []Upgrade{{Version: 0, Steps: []UpgradeStep{{Type: DefaultUpgrade, Attribute: "name"}}}},
+			Error:    "upgrades[0].steps[0]: default steps require a value",
+		},
+		{
+			TestCase: "drop_with_value",
+			Upgrades: []Upgrade{{Version: 0, Steps: []UpgradeStep{{Type: DropUpgrade, Attribute: "name", Value: &data.Value{String: &value}}}}},
+			Error:    "upgrades[0].steps[0]: drop steps don't accept a value",
+		},
+		{
+			TestCase: "wrap_different_parents",
+			Upgrades: []Upgrade{{Version: 0, Steps: []UpgradeStep{{Type: WrapUpgrade, Attributes: []string{"host", "object.port"}, To: "endpoint"}}}},
+			Error:    "upgrades[0].steps[0]: wrap steps can only move attributes within the same object or block",
+		},
+		{
+			TestCase: "id",
+			Upgrades: []Upgrade{{Version: 0, Steps: []UpgradeStep{{Type: DropUpgrade, Attribute: "id"}}}},
+			Error:    "upgrades[0].steps[0]: upgrades cannot change the id attribute",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.TestCase, func(t *testing.T) {
+			schema := Schema{
+				Version:  2,
+				Upgrades: testCase.Upgrades,
+			}
+
+			err := schema.validateUpgrades()
+			if len(testCase.Error) == 0 {
+				if err != nil {
+					t.Fatalf("expected no error but found %v", err)
+				}
+				return
+			}
+
+			if err == nil || err.Error() != testCase.Error {
+				t.Fatalf("expected %q but found %v", testCase.Error, err)
+			}
+		})
+	}
+}
+
+func TestSchema_UpgradeState(t *testing.T) {
+	zone := "zone-a"
+	schema := Schema{
+		Version: 3,
+		Upgrades: []Upgrade{
+			{
+				Version: 1,
+				Steps: []UpgradeStep{
+					{Type: DefaultUpgrade, Attribute: "zone", Value: &data.Value{String: &zone}},
+					{Type: WrapUpgrade, Attributes: []string{"host", "port"}, To: "endpoint"},
+				},
+			},
+			{
+				Version: 0,
+				Steps: []UpgradeStep{
+					{Type: RenameUpgrade, Attribute: "name", To: "title"},
+					{Type: ConvertUpgrade, Attribute: "port", To: "integer"},
+					{Type: DropUpgrade, Attribute: "legacy"},
+					{Type: RenameUpgrade, Attribute: "rules.cidr", To: "cidr_block"},
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		TestCase string
+		Version  int64
+		State    string
+		Expected string
+	}{
+		{
+			TestCase: "from_zero",
+			Version:  0,
+			State:    `{"id":"one","name":"hello","port":"8080","host":"localhost","legacy":true,"rules":[{"cidr":"10.0.0.0/8"}]}`,
+			Expected: `{"endpoint":[{"host":"localhost","port":8080}],"id":"one","rules":[{"cidr_block":"10.0.0.0/8"}],"title":"hello","zone":"zone-a"}`,
+		},
+		{
+			TestCase: "from_one",
+			Version:  1,
+			State:    `{"id":"one","title":"hello","host":null,"port":null,"zone":"zone-b"}`,
+			Expected: `{"endpoint":[],"id":"one","title":"hello","zone":"zone-b"}`,
+		},
+		{
+			TestCase: "from_two",
+			Version:  2,
+			State:    `{"id":"one","name":"hello"}`,
+			Expected: `{"id":"one","name":"hello"}`,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.TestCase, func(t *testing.T) {
+			var state map[string]any
+			decoder := json.NewDecoder(strings.NewReader(testCase.State))
+			decoder.UseNumber()
+			if err := decoder.Decode(&state); err != nil {
+				t.Fatalf("failed to decode state: %v", err)
+			}
+
+			if err := schema.UpgradeState(testCase.Version, state); err != nil {
+				t.Fatalf("expected no error but found %v", err)
+			}
+
+			actual, err := json.Marshal(state)
+			if err != nil {
+				t.Fatalf("failed to encode state: %v", err)
+			}
+			if string(actual) != testCase.Expected {
+				t.Fatalf("expected %s but found %s", testCase.Expected, actual)
+			}
+		})
+	}
+}
+
+func TestSchema_Moves(t *testing.T) {
+	testCases := []struct {
+		TestCase string
+		Moves    []Move
+		Error    string
+	}{
+		{
+			TestCase: "valid",
+			Moves: []Move{
+				{ResourceType: "tfcoremock_simple_resource", Attributes: map[string]string{"string": "name"}},
+				{ResourceType: "tfcoremock_complex_resource"},
+			},
+		},
+		{
+			TestCase: "missing_resource_type",
+			Moves:    []Move{{}},
+			Error:    "moved_from[0]: missing resource_type",
+		},
+		{
+			TestCase: "duplicate_resource_type",
+			Moves:    []Move{{ResourceType: "tfcoremock_simple_resource"}, {ResourceType: "tfcoremock_simple_resource"}},
+			Error:    "moved_from[1]: moves from tfcoremock_simple_resource are declared more than once",
+		},
+		{
+			TestCase: "id",
+			Moves:    []Move{{ResourceType: "tfcoremock_simple_resource", Attributes: map[string]string{"string": "id"}}},
+			Error:    "moved_from[0]: moves cannot change the id attribute",
+		},
+		{
+			TestCase: "missing_attribute",
+			Moves:    []Move{{ResourceType: "tfcoremock_simple_resource", Attributes: map[string]string{"string": "title"}}},
+			Error:    "moved_from[0]: string is mapped to missing attribute title",
+		},
+		{
+			TestCase: "duplicate_attribute",
+			Moves:    []Move{{ResourceType: "tfcoremock_simple_resource", Attributes: map[string]string{"number": "name", "string": "name"}}},
+			Error:    "moved_from[0]: more than one attribute is mapped to name",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.TestCase, func(t *testing.T) {
+			schema := Schema{
+				Attributes: map[string]Attribute{
+					"name": {Type: String, Optional: true},
+				},
+				Blocks: map[string]Block{
+					"endpoint": {Mode: NestingModeList},
+				},
+				MovedFrom: testCase.Moves,
+			}
+
+			err := schema.validateMoves()
+			if len(testCase.Error) == 0 {
+				if err != nil {
+					t.Fatalf("expected no error but found %v", err)
+				}
+				return
+			}
+
+			if err == nil || err.Error() != testCase.Error {
+				t.Fatalf("expected %q but found %v", testCase.Error, err)
+			}
+		})
+	}
+}
+
+func TestMove_Apply(t *testing.T) {
+	move := Move{
+		ResourceType: "tfcoremock_simple_resource",
+		Attributes: map[string]string{
+			"first":  "second",
+			"second": "first",
+			"string": "name",
+		},
+	}
+
+	state := map[string]any{
+		"id":     "my-resource",
+		"first":  "one",
+		"second": "two",
+		"string": "hello",
+		"other":  true,
+	}
+	move.Apply(state)
+
+	actual, err := json.Marshal(state)
+	if err != nil {
+		t.Fatalf("failed to encode state: %v", err)
+	}
+
+	expected := `{"first":"two","id":"my-resource","name":"hello","other":true,"second":"one"}`
+	if string(actual) != expected {
+		t.Fatalf("expected %s but found %s", expected, actual)
+	}
+}
+
+func TestSchema_WriteOnly(t *testing.T) {
+	testCases := []struct {
+		TestCase   string
+		Attributes map[string]Attribute
+		Blocks     map[string]Block
+		Error      string
+	}{
+		{
+			TestCase: "valid",
+			Attributes: map[string]Attribute{
+				"password": {Type: String, Required: true, WriteOnly: true},
+				"credentials": {
+					Type:      Object,
+					Optional:  true,
+					WriteOnly: true,
+					Object: map[string]Attribute{
+						"user": {Type: String, Optional: true, WriteOnly: true},
+					},
+				},
+			},
+			Blocks: map[string]Block{
+				"endpoint": {
+					Attributes: map[string]Attribute{
+						"token": {Type: String, Optional: true, WriteOnly: true},
+					},
+				},
+			},
+		},
+		{
+			TestCase: "computed",
+			Attributes: map[string]Attribute{
+				"password": {Type: String, Optional: true, Computed: true, WriteOnly: true},
+			},
+			Error: "attribute password: write-only attributes cannot be computed",
+		},
+		{
+			TestCase: "replace",
+			Attributes: map[string]Attribute{
+				"password": {Type: String, Optional: true, Replace: true, WriteOnly: true},
+			},
+			Error: "attribute password: write-only attributes cannot force replacement as they are never planned",
+		},
+		{
+			TestCase: "set",
+			Attributes: map[string]Attribute{
+				"passwords": {Type: Set, Optional: true, WriteOnly: true, Set: &Attribute{Type: String}},
+			},
+			Error: "attribute passwords: write-only attributes cannot be sets or within sets",
+		},
+		{
+			TestCase: "set_block",
+			Blocks: map[string]Block{
+				"endpoint": {
+					Mode: NestingModeSet,
+					Attributes: map[string]Attribute{
+						"token": {Type: String, Optional: true, WriteOnly: true},
+					},
+				},
+			},
+			Error: "block endpoint: attribute token: write-only attributes cannot be sets or within sets",
+		},
+		{
+			TestCase: "nested",
+			Attributes: map[string]Attribute{
+				"credentials": {
+					Type:      List,
+					Optional:  true,
+					WriteOnly: true,
+					List: &Attribute{
+						Type: Object,
+						Object: map[string]Attribute{
+							"user": {Type: String, Optional: true},
+						},
+					},
+				},
+			},
+			Error: "attribute credentials: attribute user: attributes within write-only attributes must also be write-only",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.TestCase, func(t *testing.T) {
+			schema := Schema{
+				Attributes: testCase.Attributes,
+				Blocks:     testCase.Blocks,
+			}
+
+			err := schema.validateAttributes()
+			if len(testCase.Error) == 0 {
+				if err != nil {
+					t.Fatalf("expected no error but found %v", err)
+				}
+				return
+			}
+
+			if err == nil || err.Error() != testCase.Error {
+				t.Fatalf("expected %q but found %v", testCase.Error, err)
+			}
+		})
+	}
+}
+
+func TestSchema_ToTerraformEphemeralResourceSchema(t *testing.T) {
+	testCases := []struct {
+		TestCase string
+		Schema   Schema
+		RenewAt  bool
+	}{
+		{
+			TestCase: "renew_at",
+			Schema: Schema{
+				Attributes: map[string]Attribute{
+					"name": {Type: String, Optional: true},
+				},
+			},
+			RenewAt: true,
+		},
+		{
+			TestCase: "existing_attribute",
+			Schema: Schema{
+				Attributes: map[string]Attribute{
+					"renew_at": {Type: Integer, Required: true},
+				},
+			},
+		},
+		{
+			TestCase: "existing_block",
+			Schema: Schema{
+				Blocks: map[string]Block{
+					"renew_at": {},
+				},
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.TestCase, func(t *testing.T) {
+			out, err := testCase.Schema.ToTerraformEphemeralResourceSchema()
+			if err != nil {
+				t.Fatalf("expected no error but found %v", err)
+			}
+
+			if _, ok := out.Attributes["id"]; !ok {
+				t.Fatalf("expected an id attribute")
+			}
+
+			if testCase.Schema.HasRenewAt() != testCase.RenewAt {
+				t.Fatalf("expected HasRenewAt to return %t", testCase.RenewAt)
+			}
+			if attribute, ok := out.Attributes[RenewAt]; ok && testCase.RenewAt && !attribute.IsOptional() {
+				t.Fatalf("expected renew_at to be optional")
+			}
+		})
+	}
+}
+
+func TestFunction_Validate(t *testing.T) {
+	name := "name"
+	testCases := []struct {
+		TestCase string
+		Function Function
+		Error    string
+	}{
+		{
+			TestCase: "result",
+			Function: Function{
+				Parameters: []Parameter{{Name: "input", FunctionType: FunctionType{Type: String}}},
+				Return:     FunctionType{Type: String},
+				Result:     &data.Value{String: &name},
+			},
+		},
+		{
+			TestCase: "echo",
+			Function: Function{
+				Parameters: []Parameter{{Name: "input", FunctionType: FunctionType{Type: List, List: &FunctionType{Type: String}}}},
+				Return:     FunctionType{Type: List, List: &FunctionType{Type: String}},
+				Echo:       "input",
+			},
+		},
+		{
+			TestCase: "variadic_results",
+			Function: Function{
+				Parameters:        []Parameter{{Name: "first", FunctionType: FunctionType{Type: String}}},
+				VariadicParameter: &Parameter{Name: "rest", FunctionType: FunctionType{Type: String}},
+				Return:            FunctionType{Type: String},
+				Results: []FunctionResult{
+					{Arguments: []data.Value{{String: &name}, {String: &name}, {String: &name}}, Result: data.Value{String: &name}},
+				},
+			},
+		},
+		{
+			TestCase: "missing_return",
+			Function: Function{Result: &data.Value{String: &name}},
+			Error:    "return: missing type",
+		},
+		{
+			TestCase: "missing_element",
+			Function: Function{
+				Return: FunctionType{Type: List},
+				Result: &data.Value{String: &name},
+			},
+			Error: "return: missing element type for list",
+		},
+		{
+			TestCase: "missing_name",
+			Function: Function{
+				Parameters: []Parameter{{FunctionType: FunctionType{Type: String}}},
+				Return:     FunctionType{Type: String},
+				Result:     &data.Value{String: &name},
+			},
+			Error: "parameters[0]: missing name",
+		},
+		{
+			TestCase: "duplicate_name",
+			Function: Function{
+				Parameters:        []Parameter{{Name: "input", FunctionType: FunctionType{Type: String}}},
+				VariadicParameter: &Parameter{Name: "input", FunctionType: FunctionType{Type: String}},
+				Return:            FunctionType{Type: String},
+				Result:            &data.Value{String: &name},
+			},
+			Error: "parameters[1]: parameter input is declared more than once",
+		},
+		{
+			TestCase: "missing_result",
+			Function: Function{Return: FunctionType{Type: String}},
+			Error:    "one of result, results, or echo must be set",
+		},
+		{
+			TestCase: "echo_and_result",
+			Function: Function{
+				Parameters: []Parameter{{Name: "input", FunctionType: FunctionType{Type: String}}},
+				Return:     FunctionType{Type: String},
+				Result:     &data.Value{String: &name},
+				Echo:       "input",
+			},
+			Error: "echo cannot be used with result or results",
+		},
+		{
+			TestCase: "echo_variadic",
+			Function: Function{
+				VariadicParameter: &Parameter{Name: "input", FunctionType: FunctionType{Type: String}},
+				Return:            FunctionType{Type: String},
+				Echo:              "input",
+			},
+			Error: "echo: input is not a parameter, or is the variadic parameter",
+		},
+		{
+			TestCase: "echo_type",
+			Function: Function{
+				Parameters: []Parameter{{Name: "input", FunctionType: FunctionType{Type: Number}}},
+				Return:     FunctionType{Type: String},
+				Echo:       "input",
+			},
+			Error: "echo: parameter input does not match the return type",
+		},
+		{
+			TestCase: "results_arguments",
+			Function: Function{
+				Parameters: []Parameter{{Name: "input", FunctionType: FunctionType{Type: String}}},
+				Return:     FunctionType{Type: String},
+				Results: []FunctionResult{
+					{Arguments: []data.Value{{String: &name}, {String: &name}}, Result: data.Value{String: &name}},
+				},
+			},
+			Error: "results[0]: wrong number of arguments",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.TestCase, func(t *testing.T) {
+			err := testCase.Function.Validate()
+			if len(testCase.Error) == 0 {
+				if err != nil {
+					t.Fatalf("expected no error but found %v", err)
+				}
+				if _, err := testCase.Function.ToTerraformFunctionDefinition(); err != nil {
+					t.Fatalf("expected no error but found %v", err)
+				}
+				return
+			}
+
+			if err == nil || err.Error() != testCase.Error {
+				t.Fatalf("expected %q but found %v", testCase.Error, err)
+			}
+		})
+	}
+}
+
+func TestFunction_Evaluate(t *testing.T) {
+	var function Function
+	if err := json.Unmarshal([]byte(`{
+		"parameters": [{"name": "region", "type": "string"}],
+		"variadic_parameter": {"name": "sizes", "type": "number"},
+		"return": {"type": "string"},
+		"result": {"string": "default"},
+		"results": [
+			{"arguments": [{"string": "us-east-1"}], "result": {"string": "none"}},
+			{"arguments": [{"string": "us-east-1"}, {"number": "1.5"}], "result": {"string": "small"}},
+			{"arguments": [{"string": "us-east-1"}, {"number": "0.1"}], "result": {"string": "tiny"}}
+		]
+	}`), &function); err != nil {
+		t.Fatalf("failed to decode function: %v", err)
+	}
+
+	// Terraform sends numbers with 512 bits of precision, while numbers in
+	// the JSON are parsed with 64 bits.
+	precise, _, err := big.ParseFloat("0.1", 10, 512, big.ToNearestEven)
+	if err != nil {
+		t.Fatalf("failed to parse number: %v", err)
+	}
+	fractional, err := data.FromTerraform5Value(tftypes.NewValue(tftypes.Number, precise))
+	if err != nil {
+		t.Fatalf("failed to convert number: %v", err)
+	}
+
+	region := "us-east-1"
+	other := "eu-west-2"
+	testCases := []struct {
+		TestCase  string
+		Arguments []data.Value
+		Expected  string
+	}{
+		{
+			TestCase:  "no_variadic_arguments",
+			Arguments: []data.Value{{String: &region}},
+			Expected:  "none",
+		},
+		{
+			TestCase:  "variadic_arguments",
+			Arguments: []data.Value{{String: &region}, {Number: big.NewFloat(1.5)}},
+			Expected:  "small",
+		},
+		{
+			TestCase:  "fractional_arguments",
+			Arguments: []data.Value{{String: &region}, fractional},
+			Expected:  "tiny",
+		},
+		{
+			TestCase:  "default",
+			Arguments: []data.Value{{String: &other}},
+			Expected:  "default",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.TestCase, func(t *testing.T) {
+			result, err := function.Evaluate(testCase.Arguments)
+			if err != nil {
+				t.Fatalf("expected no error but found %v", err)
+			}
+			if result.String == nil || *result.String != testCase.Expected {
+				t.Fatalf("expected %s but found %v", testCase.Expected, result.String)
+			}
+		})
+	}
+}
+
+func TestSchema_Validators(t *testing.T) {
+	one := 1.0
+	two := 2.0
+	testCases := []struct {
+		TestCase   string
+		Attributes map[string]Attribute
+		Blocks     map[string]Block
+		Error      string
+	}{
+		{
+			TestCase: "valid",
+			Attributes: map[string]Attribute{
+				"name":  {Type: String, Required: true, Validators: []Validator{{Type: LengthValidator, Min: &one}, {Type: RegexValidator, Pattern: "^[a-z]+$"}}},
+				"count": {Type: Integer, Optional: true, Computed: true, Validators: []Validator{{Type: RangeValidator, Min: &one, Max: &two}}},
+				"tags":  {Type: Map, Optional: true, Map: &Attribute{Type: String}, Validators: []Validator{{Type: SizeValidator, Max: &two}}},
+				"rules": {
+					Type:     List,
+					Optional: true,
+					List: &Attribute{
+						Type: Object,
+						Object: map[string]Attribute{
+							"port": {Type: Number, Optional: true, Validators: []Validator{{Type: OneOfValidator, Values: []data.Value{{Number: big.NewFloat(80)}}}}},
+						},
+					},
+				},
+			},
+			Blocks: map[string]Block{
+				"endpoint": {
+					Attributes: map[string]Attribute{
+						"region": {Type: String, Optional: true, Validators: []Validator{{Type: OneOfValidator, Values: []data.Value{{String: new(string)}}}}},
+					},
+				},
+			},
+		},
+		{
+			TestCase: "computed",
+			Attributes: map[string]Attribute{
+				"name": {Type: String, Computed: true, Validators: []Validator{{Type: LengthValidator, Min: &one}}},
+			},
+			Error: "attribute name: only optional or required attributes can have validators",
+		},
+		{
+			TestCase: "wrong_type",
+			Attributes: map[string]Attribute{
+				"name": {Type: String, Optional: true, Validators: []Validator{{Type: RangeValidator, Min: &one}}},
+			},
+			Error: "attribute name: validators[0]: range validators cannot be applied to string attributes",
+		},
+		{
+			TestCase: "missing_bounds",
+			Attributes: map[string]Attribute{
+				"tags": {Type: Set, Optional: true, Set: &Attribute{Type: String}, Validators: []Validator{{Type: SizeValidator}}},
+			},
+			Error: "attribute tags: validators[0]: size validators must set at least one of min and max",
+		},
+		{
+			TestCase: "inverted_bounds",
+			Attributes: map[string]Attribute{
+				"count": {Type: Float, Optional: true, Validators: []Validator{{Type: RangeValidator, Min: &two, Max: &one}}},
+			},
+			Error: "attribute count: validators[0]: min cannot be greater than max",
+		},
+		{
+			TestCase: "invalid_pattern",
+			Attributes: map[string]Attribute{
+				"name": {Type: String, Optional: true, Validators: []Validator{{Type: RegexValidator, Pattern: "["}}},
+			},
+			Error: "attribute name: validators[0]: invalid pattern: error parsing regexp: missing closing ]: `[`",
+		},
+		{
+			TestCase: "missing_values",
+			Attributes: map[string]Attribute{
+				"name": {Type: String, Optional: true, Validators: []Validator{{Type: OneOfValidator}}},
+			},
+			Error: "attribute name: validators[0]: one_of validators must have at least one value",
+		},
+		{
+			TestCase: "element",
+			Attributes: map[string]Attribute{
+				"names": {Type: List, Optional: true, List: &Attribute{Type: String, Validators: []Validator{{Type: LengthValidator, Min: &one}}}},
+			},
+			Error: "attribute names: validators cannot be set on elements or within objects that skip nested metadata",
+		},
+		{
+			TestCase: "skip_nested_metadata",
+			Attributes: map[string]Attribute{
+				"network": {
+					Type:               Object,
+					Optional:           true,
+					SkipNestedMetadata: true,
+					Object: map[string]Attribute{
+						"zone": {Type: String, Optional: true, Validators: []Validator{{Type: LengthValidator, Min: &one}}},
+					},
+				},
+			},
+			Error: "attribute network: attribute zone: validators cannot be set on elements or within objects that skip nested metadata",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.TestCase, func(t *testing.T) {
+			schema := Schema{
+				Attributes: testCase.Attributes,
+				Blocks:     testCase.Blocks,
+			}
+
+			err := schema.validateAttributes()
+			if len(testCase.Error) == 0 {
+				if err != nil {
+					t.Fatalf("expected no error but found %v", err)
+				}
+				return
+			}
+
+			if err == nil || err.Error() != testCase.Error {
+				t.Fatalf("expected %q but found %v", testCase.Error, err)
+			}
+		})
+	}
+}
+
+func TestSchema_AttributeValidators(t *testing.T) {
+	three := 3.0
+	validators := []Validator{{Type: LengthValidator, Max: &three}}
+	schema := Schema{
+		Attributes: map[string]Attribute{
+			"name": {
+				Type:       String,
+				Required:   true,
+				Validators: validators,
+			},
+			"unvalidated": {
+				Type:     String,
+				Optional: true,
+			},
+			"object": {
+				Type: Object,
+				Object: map[string]Attribute{
+					"name": {
+						Type:       String,
+						Optional:   true,
+						Validators: validators,
+					},
+				},
+				Optional: true,
+			},
+			"list": {
+				Type: List,
+				List: &Attribute{
+					Type: Object,
+					Object: map[string]Attribute{
+						"name": {
+							Type:       String,
+							Optional:   true,
+							Validators: validators,
+						},
+					},
+				},
+				Optional: true,
+			},
+		},
+		Blocks: map[string]Block{
+			"set": {
+				Attributes: map[string]Attribute{
+					"name": {
+						Type:       String,
+						Optional:   true,
+						Validators: validators,
+					},
+				},
+				Mode: NestingModeSet,
+			},
+		},
+	}
+
+	var actual []string
+	for _, attribute := range schema.AttributeValidators() {
+		actual = append(actual, attribute.Expression.String())
+	}
+	expected := []string{"list[*].name", "name", "object.name", "set[Value(*)].name"}
+	if !slices.Equal(actual, expected) {
+		t.Fatalf("expected %v but found %v", expected, actual)
+	}
+}
+
+func TestValidator_Diagnostics(t *testing.T) {
+	three := 3.0
+	ctx := context.Background()
+	attribute := path.Root("name")
+	region := "us-east-1"
+	testCases := []struct {
+		TestCase  string
+		Validator Validator
+		Validate  func(v Validator) diag.Diagnostics
+		Error     string
+	}{
+		{
+			TestCase:  "length",
+			Validator: Validator{Type: LengthValidator, Max: &three},
+			Validate: func(v Validator) diag.Diagnostics {
+				var response validator.StringResponse
+				v.ValidateString(ctx, validator.StringRequest{Path: attribute, ConfigValue: types.StringValue("hello")}, &response)
+				return response.Diagnostics
+			},
+			Error: "Attribute name string length must be at most 3, got: 5",
+		},
+		{
+			TestCase:  "length_valid",
+			Validator: Validator{Type: LengthValidator, Max: &three},
+			Validate: func(v Validator) diag.Diagnostics {
+				var response validator.StringResponse
+				v.ValidateString(ctx, validator.StringRequest{Path: attribute, ConfigValue: types.StringValue("héé")}, &response)
+				return response.Diagnostics
+			},
+		},
+		{
+			TestCase:  "regex",
+			Validator: Validator{Type: RegexValidator, Pattern: "^[a-z]+$", Message: "names must be lowercase"},
+			Validate: func(v Validator) diag.Diagnostics {
+				var response validator.StringResponse
+				v.ValidateString(ctx, validator.StringRequest{Path: attribute, ConfigValue: types.StringValue("Hello")}, &response)
+				return response.Diagnostics
+			},
+			Error: "names must be lowercase",
+		},
+		{
+			TestCase:  "one_of",
+			Validator: Validator{Type: OneOfValidator, Values: []data.Value{{String: &region}}},
+			Validate: func(v Validator) diag.Diagnostics {
+				var response validator.StringResponse
+				v.ValidateString(ctx, validator.StringRequest{Path: attribute, ConfigValue: types.StringValue("eu-west-2")}, &response)
+				return response.Diagnostics
+			},
+			Error: `Attribute name value must be one of: ["us-east-1"], got: "eu-west-2"`,
+		},
+		{
+			TestCase:  "one_of_float",
+			Validator: Validator{Type: OneOfValidator, Values: []data.Value{{Number: big.NewFloat(0.1)}}},
+			Validate: func(v Validator) diag.Diagnostics {
+				var response validator.Float64Response
+				v.ValidateFloat64(ctx, validator.Float64Request{Path: attribute, ConfigValue: types.Float64Value(0.1)}, &response)
+				return response.Diagnostics
+			},
+		},
+		{
+			TestCase:  "range",
+			Validator: Validator{Type: RangeValidator, Max: &three},
+			Validate: func(v Validator) diag.Diagnostics {
+				var response validator.Int64Response
+				v.ValidateInt64(ctx, validator.Int64Request{Path: attribute, ConfigValue: types.Int64Value(4)}, &response)
+				return response.Diagnostics
+			},
+			Error: "Attribute name value must be at most 3, got: 4",
+		},
+		{
+			TestCase:  "size",
+			Validator: Validator{Type: SizeValidator, Min: &three, Max: &three},
+			Validate: func(v Validator) diag.Diagnostics {
+				var response validator.ListResponse
+				v.ValidateList(ctx, validator.ListRequest{Path: attribute, ConfigValue: types.ListValueMust(types.StringType, nil)}, &response)
+				return response.Diagnostics
+			},
+			Error: "Attribute name number of elements must be between 3 and 3, got: 0",
+		},
+		{
+			TestCase:  "value",
+			Validator: Validator{Type: RangeValidator, Max: &three},
+			Validate: func(v Validator) diag.Diagnostics {
+				return v.ValidateValue(ctx, attribute, types.NumberValue(big.NewFloat(4)))
+			},
+			Error: "Attribute name value must be at most 3, got: 4",
+		},
+		{
+			TestCase:  "unknown",
+			Validator: Validator{Type: SizeValidator, Min: &three},
+			Validate: func(v Validator) diag.Diagnostics {
+				var response validator.SetResponse
+				v.ValidateSet(ctx, validator.SetRequest{Path: attribute, ConfigValue: types.SetUnknown(types.StringType)}, &response)
+				return response.Diagnostics
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.TestCase, func(t *testing.T) {
+			diags := testCase.Validate(testCase.Validator)
+			if len(testCase.Error) == 0 {
+				if diags.HasError() {
+					t.Fatalf("expected no error but found %v", diags)
+				}
+				return
+			}
+
+			if len(diags) != 1 || diags[0].Detail() != testCase.Error {
+				t.Fatalf("expected %q but found %v", testCase.Error, diags)
+			}
+		})
+	}
+}
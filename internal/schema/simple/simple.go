@@ -44,5 +44,11 @@ var (
 				Type:                schema.Integer,
 			},
 		},
+
+		// Complex resources can be moved into simple resources, dropping
+		// everything but the primitive attributes the two have in common.
+		MovedFrom: []schema.Move{
+			{ResourceType: "tfcoremock_complex_resource"},
+		},
 	}
 )
@@ -0,0 +1,110 @@
+// Copyright IBM Corp. 2022, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package schema
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var templateReference = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_-]*(\.[a-zA-Z_][a-zA-Z0-9_-]*)*$`)
+
+// Template is a string holding references to other attributes in the form of
+// `${name}` or `${object.name}`. References are always relative to the root of
+// the resource. A literal `${` is written as `$${`.
+type Template string
+
+// templatePart is either a literal string, or a reference to an attribute.
+type templatePart struct {
+	literal   string
+	reference []string
+}
+
+// References returns the paths of every attribute referenced by the template.
+func (t Template) References() ([][]string, error) {
+	parts, err := t.parse()
+	if err != nil {
+		return nil, err
+	}
+
+	var references [][]string
+	for _, part := range parts {
+		if part.reference != nil {
+			references = append(references, part.reference)
+		}
+	}
+	return references, nil
+}
+
+// Render substitutes each reference in the template with the value returned
+// by resolve. It returns false if resolve reports any of the referenced values
+// as unknown.
+func (t Template) Render(resolve func(reference []string) (string, bool, error)) (string, bool, error) {
+	parts, err := t.parse()
+	if err != nil {
+		return "", false, err
+	}
+
+	var builder strings.Builder
+	for _, part := range parts {
+		if part.reference == nil {
+			builder.WriteString(part.literal)
+			continue
+		}
+
+		value, known, err := resolve(part.reference)
+		if err != nil {
+			return "", false, fmt.Errorf("failed to resolve %s: %w", strings.Join(part.reference, "."), err)
+		}
+		if !known {
+			return "", false, nil
+		}
+		builder.WriteString(value)
+	}
+	return builder.String(), true, nil
+}
+
+func (t Template) parse() ([]templatePart, error) {
+	var parts []templatePart
+
+	remaining := string(t)
+	var literal strings.Builder
+	for len(remaining) > 0 {
+		if strings.HasPrefix(remaining, "$${") {
+			literal.WriteString("${")
+			remaining = remaining[3:]
+			continue
+		}
+
+		if !strings.HasPrefix(remaining, "${") {
+			literal.WriteByte(remaining[0])
+			remaining = remaining[1:]
+			continue
+		}
+
+		end := strings.Index(remaining, "}")
+		if end < 0 {
+			return nil, errors.New("unterminated reference in template")
+		}
+
+		reference := strings.TrimSpace(remaining[2:end])
+		if !templateReference.MatchString(reference) {
+			return nil, fmt.Errorf("invalid reference '%s' in template", reference)
+		}
+
+		if literal.Len() > 0 {
+			parts = append(parts, templatePart{literal: literal.String()})
+			literal.Reset()
+		}
+		parts = append(parts, templatePart{reference: strings.Split(reference, ".")})
+		remaining = remaining[end+1:]
+	}
+
+	if literal.Len() > 0 {
+		parts = append(parts, templatePart{literal: literal.String()})
+	}
+	return parts, nil
+}
@@ -0,0 +1,394 @@
+// Copyright IBM Corp. 2022, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package schema
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-provider-tfcoremock/internal/data"
+)
+
+type UpgradeType string
+
+const (
+	RenameUpgrade  UpgradeType = "rename"
+	ConvertUpgrade UpgradeType = "convert"
+	DropUpgrade    UpgradeType = "drop"
+	DefaultUpgrade UpgradeType = "default"
+	WrapUpgrade    UpgradeType = "wrap"
+)
+
+// Upgrade describes how to upgrade the state of a resource from one version of
+// its schema to the next.
+type Upgrade struct {
+	// Version is the version of the schema this upgrades the state from. The
+	// state is upgraded to the following version.
+	Version int64 `json:"version"`
+
+	Steps []UpgradeStep `json:"steps"`
+}
+
+// UpgradeStep is a single change made to the state of a resource while it is
+// upgraded.
+//
+// Attributes are named by their path from the top level of the resource, with
+// the names of nested objects and blocks separated by dots, for example
+// `network.zone`. Steps apply to every element of any lists or sets of blocks
+// along the path.
+type UpgradeStep struct {
+	Type UpgradeType `json:"type"`
+
+	// Attribute is the attribute changed by rename, convert, drop and default
+	// steps.
+	Attribute string `json:"attribute,omitempty"`
+
+	// Attributes are the attributes moved into a new block by wrap steps. They
+	// must all be within the same object or block.
+	Attributes []string `json:"attributes,omitempty"`
+
+	// To is the new name of the attribute for rename steps, the name of the
+	// new block for wrap steps, and the new type of the attribute for convert
+	// steps.
+	To string `json:"to,omitempty"`
+
+	// Value is set by default steps when the attribute is missing or null.
+	Value *data.Value `json:"value,omitempty"`
+}
+
+// Validate checks the step has everything it needs for its type.
+func (step UpgradeStep) Validate() error {
+	switch step.Type {
+	case RenameUpgrade, ConvertUpgrade, DropUpgrade, DefaultUpgrade:
+		if len(step.Attribute) == 0 {
+			return fmt.Errorf("%s steps require an attribute", step.Type)
+		}
+		if len(step.Attributes) > 0 {
+			return fmt.Errorf("%s steps don't accept attributes", step.Type)
+		}
+		if err := validateUpgradePath(step.Attribute); err != nil {
+			return err
+		}
+	case WrapUpgrade:
+		if len(step.Attributes) == 0 {
+			return errors.New("wrap steps require attributes")
+		}
+		if len(step.Attribute) > 0 {
+			return errors.New("wrap steps don't accept an attribute, use attributes instead")
+		}
+		parent := upgradeParent(step.Attributes[0])
+		for _, attribute := range step.Attributes {
+			if err := validateUpgradePath(attribute); err != nil {
+				return err
+			}
+			if upgradeParent(attribute) != parent {
+				return errors.New("wrap steps can only move attributes within the same object or block")
+			}
+		}
+	case "":
+		return errors.New("missing upgrade step type")
+	default:
+		return fmt.Errorf("unrecognized upgrade step type '%s'", step.Type)
+	}
+
+	switch step.Type {
+	case RenameUpgrade, WrapUpgrade:
+		if len(step.To) == 0 || strings.Contains(step.To, ".") {
+			return fmt.Errorf("%s steps require a new name in to, without any dots", step.Type)
+		}
+		topLevel := !strings.Contains(step.Attribute, ".")
+		if step.Type == WrapUpgrade {
+			topLevel = len(upgradeParent(step.Attributes...)) == 0
+		}
+		if topLevel && step.To == "id" {
+			return fmt.Errorf("%s steps cannot replace the id attribute", step.Type)
+		}
+	case ConvertUpgrade:
+		switch Type(step.To) {
+		case Boolean, Float, Integer, Number, String:
+		default:
+			return fmt.Errorf("convert steps can only convert to primitive types, not '%s'", step.To)
+		}
+	default:
+		if len(step.To) > 0 {
+			return fmt.Errorf("%s steps don't accept to", step.Type)
+		}
+	}
+
+	if (step.Value != nil) != (step.Type == DefaultUpgrade) {
+		if step.Value == nil {
+			return errors.New("default steps require a value")
+		}
+		return fmt.Errorf("%s steps don't accept a value", step.Type)
+	}
+	return nil
+}
+
+// Apply makes the change described by the step to the state, which holds the
+// top level values of a resource in the same JSON format Terraform uses for
+// its state files. Numbers must be decoded as json.Number.
+func (step UpgradeStep) Apply(state map[string]any) error {
+	switch step.Type {
+	case RenameUpgrade:
+		return walkUpgradePath(state, step.Attribute, func(object map[string]any, name string) error {
+			if value, ok := object[name]; ok {
+				delete(object, name)
+				object[step.To] = value
+			}
+			return nil
+		})
+	case ConvertUpgrade:
+		return walkUpgradePath(state, step.Attribute, func(object map[string]any, name string) error {
+			value, err := convertUpgradeValue(object[name], Type(step.To))
+			if err != nil {
+				return fmt.Errorf("failed to convert %s: %w", step.Attribute, err)
+			}
+			if _, ok := object[name]; ok {
+				object[name] = value
+			}
+			return nil
+		})
+	case DropUpgrade:
+		return walkUpgradePath(state, step.Attribute, func(object map[string]any, name string) error {
+			delete(object, name)
+			return nil
+		})
+	case DefaultUpgrade:
+		return walkUpgradePath(state, step.Attribute, func(object map[string]any, name string) error {
+			if object[name] == nil {
+				object[name] = upgradeValue(*step.Value)
+			}
+			return nil
+		})
+	case WrapUpgrade:
+		var names []string
+		for _, attribute := range step.Attributes {
+			names = append(names, attribute[strings.LastIndex(attribute, ".")+1:])
+		}
+
+		wrap := func(object map[string]any) {
+			wrapped := make(map[string]any)
+			for _, name := range names {
+				if value := object[name]; value != nil {
+					wrapped[name] = value
+				}
+				delete(object, name)
+			}
+
+			// We only make an element in the new block if any of the moved
+			// attributes had a value, as an empty block is different to a
+			// missing one.
+			object[step.To] = []any{}
+			if len(wrapped) > 0 {
+				object[step.To] = []any{wrapped}
+			}
+		}
+
+		parent := upgradeParent(step.Attributes...)
+		if len(parent) == 0 {
+			wrap(state)
+			return nil
+		}
+		return walkUpgradePath(state, parent, func(object map[string]any, name string) error {
+			return walkUpgradeValue(object[name], nil, func(object map[string]any, _ string) error {
+				wrap(object)
+				return nil
+			})
+		})
+	default:
+		return fmt.Errorf("unrecognized upgrade step type '%s'", step.Type)
+	}
+}
+
+// validateUpgrades checks the upgrades all apply to earlier versions of the
+// schema, and that no version is upgraded twice.
+func (schema Schema) validateUpgrades() error {
+	if schema.Version < 0 {
+		return errors.New("version cannot be negative")
+	}
+
+	seen := make(map[int64]bool)
+	for ix, upgrade := range schema.Upgrades {
+		if upgrade.Version < 0 || upgrade.Version >= schema.Version {
+			return fmt.Errorf("upgrades[%d]: version %d is not before the current version %d", ix, upgrade.Version, schema.Version)
+		}
+		if seen[upgrade.Version] {
+			return fmt.Errorf("upgrades[%d]: version %d is upgraded more than once", ix, upgrade.Version)
+		}
+		seen[upgrade.Version] = true
+
+		for jx, step := range upgrade.Steps {
+			if err := step.Validate(); err != nil {
+				return fmt.Errorf("upgrades[%d].steps[%d]: %w", ix, jx, err)
+			}
+		}
+	}
+	return nil
+}
+
+// UpgradeState upgrades the state of a resource from the given version to the
+// current version of the schema, applying the steps for each version in turn.
+// Versions without any upgrades are left unchanged.
+func (schema Schema) UpgradeState(version int64, state map[string]any) error {
+	upgrades := slices.Clone(schema.Upgrades)
+	slices.SortFunc(upgrades, func(left, right Upgrade) int {
+		return cmp.Compare(left.Version, right.Version)
+	})
+
+	for _, upgrade := range upgrades {
+		if upgrade.Version < version {
+			continue
+		}
+		for ix, step := range upgrade.Steps {
+			if err := step.Apply(state); err != nil {
+				return fmt.Errorf("failed to upgrade from version %d, step %d: %w", upgrade.Version, ix, err)
+			}
+		}
+	}
+	return nil
+}
+
+func validateUpgradePath(path string) error {
+	for _, name := range strings.Split(path, ".") {
+		if len(name) == 0 {
+			return fmt.Errorf("invalid attribute path '%s'", path)
+		}
+	}
+	if path == "id" {
+		return errors.New("upgrades cannot change the id attribute")
+	}
+	return nil
+}
+
+// upgradeParent returns the path of the object or block holding the given
+// attributes, which is empty for top level attributes.
+func upgradeParent(paths ...string) string {
+	if len(paths) == 0 {
+		return ""
+	}
+	if ix := strings.LastIndex(paths[0], "."); ix >= 0 {
+		return paths[0][:ix]
+	}
+	return ""
+}
+
+// walkUpgradePath calls fn with every object holding the attribute at the given
+// path, along with the name of the attribute within the object.
+func walkUpgradePath(state map[string]any, path string, fn func(object map[string]any, name string) error) error {
+	return walkUpgradeValue(state, strings.Split(path, "."), fn)
+}
+
+func walkUpgradeValue(value any, path []string, fn func(object map[string]any, name string) error) error {
+	switch value := value.(type) {
+	case nil:
+		return nil
+	case []any:
+		for _, element := range value {
+			if err := walkUpgradeValue(element, path, fn); err != nil {
+				return err
+			}
+		}
+		return nil
+	case map[string]any:
+		if len(path) == 0 {
+			return fn(value, "")
+		}
+		if len(path) == 1 {
+			return fn(value, path[0])
+		}
+		return walkUpgradeValue(value[path[0]], path[1:], fn)
+	default:
+		return fmt.Errorf("expected an object or block at %s", strings.Join(path, "."))
+	}
+}
+
+// convertUpgradeValue converts a primitive value in the state to the given
+// type.
+func convertUpgradeValue(value any, to Type) (any, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	var str string
+	switch value := value.(type) {
+	case bool:
+		if to != Boolean && to != String {
+			return nil, fmt.Errorf("cannot convert a boolean to %s", to)
+		}
+		str = strconv.FormatBool(value)
+	case json.Number:
+		if to == Boolean {
+			return nil, fmt.Errorf("cannot convert a number to %s", to)
+		}
+		str = value.String()
+	case string:
+		str = value
+	default:
+		return nil, fmt.Errorf("cannot convert a complex value to %s", to)
+	}
+
+	switch to {
+	case Boolean:
+		converted, err := strconv.ParseBool(str)
+		if err != nil {
+			return nil, fmt.Errorf("cannot convert '%s' to %s", str, to)
+		}
+		return converted, nil
+	case Integer:
+		if _, err := strconv.ParseInt(str, 10, 64); err != nil {
+			return nil, fmt.Errorf("cannot convert '%s' to %s", str, to)
+		}
+		return json.Number(str), nil
+	case Float, Number:
+		if _, err := strconv.ParseFloat(str, 64); err != nil {
+			return nil, fmt.Errorf("cannot convert '%s' to %s", str, to)
+		}
+		return json.Number(str), nil
+	default:
+		return str, nil
+	}
+}
+
+// upgradeValue converts a value from the format used by dynamic_resources.json
+// into the format used by the state.
+func upgradeValue(value data.Value) any {
+	elements := func(values []data.Value) []any {
+		out := make([]any, 0, len(values))
+		for _, value := range values {
+			out = append(out, upgradeValue(value))
+		}
+		return out
+	}
+	attributes := func(values map[string]data.Value) map[string]any {
+		out := make(map[string]any, len(values))
+		for name, value := range values {
+			out[name] = upgradeValue(value)
+		}
+		return out
+	}
+
+	switch {
+	case value.Boolean != nil:
+		return *value.Boolean
+	case value.Number != nil:
+		return json.Number(value.Number.Text('f', -1))
+	case value.String != nil:
+		return *value.String
+	case value.List != nil:
+		return elements(*value.List)
+	case value.Set != nil:
+		return elements(*value.Set)
+	case value.Map != nil:
+		return attributes(*value.Map)
+	case value.Object != nil:
+		return attributes(*value.Object)
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,382 @@
+// Copyright IBM Corp. 2022, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package schema
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"math/big"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/hashicorp/terraform-provider-tfcoremock/internal/data"
+)
+
+type ValidatorType string
+
+const (
+	LengthValidator ValidatorType = "length"
+	RegexValidator  ValidatorType = "regex"
+	OneOfValidator  ValidatorType = "one_of"
+	RangeValidator  ValidatorType = "range"
+	SizeValidator   ValidatorType = "size"
+)
+
+var (
+	_ validator.String  = Validator{}
+	_ validator.Int64   = Validator{}
+	_ validator.Float64 = Validator{}
+	_ validator.Number  = Validator{}
+	_ validator.List    = Validator{}
+	_ validator.Map     = Validator{}
+	_ validator.Set     = Validator{}
+)
+
+// Validator checks the value of an attribute in the configuration, and returns
+// an error diagnostic for the attribute if the value is invalid.
+//
+// The supported validators are:
+//   - length: the number of characters in a string is between Min and Max.
+//   - regex: a string matches Pattern.
+//   - one_of: a string or number is one of Values.
+//   - range: a number is between Min and Max.
+//   - size: the number of elements in a list, map, or set is between Min and
+//     Max.
+//
+// Min and Max are both inclusive and optional, but at least one must be set.
+// Message replaces the detail of the diagnostic, if set.
+type Validator struct {
+	Type ValidatorType `json:"type"`
+
+	Min     *float64     `json:"min,omitempty"`
+	Max     *float64     `json:"max,omitempty"`
+	Pattern string       `json:"pattern,omitempty"`
+	Values  []data.Value `json:"values,omitempty"`
+
+	Message string `json:"message,omitempty"`
+}
+
+// Validate checks the validator is well-formed and can be applied to
+// attributes of the given type.
+func (v Validator) Validate(t Type) error {
+	var types []Type
+	switch v.Type {
+	case LengthValidator:
+		types = []Type{String}
+	case RegexValidator:
+		types = []Type{String}
+		if _, err := regexp.Compile(v.Pattern); err != nil {
+			return fmt.Errorf("invalid pattern: %w", err)
+		}
+	case OneOfValidator:
+		types = []Type{Float, Integer, Number, String}
+		if len(v.Values) == 0 {
+			return errors.New("one_of validators must have at least one value")
+		}
+	case RangeValidator:
+		types = []Type{Float, Integer, Number}
+	case SizeValidator:
+		types = []Type{List, Map, Set}
+	case "":
+		return errors.New("missing validator type")
+	default:
+		return fmt.Errorf("unrecognized validator type '%s'", v.Type)
+	}
+
+	if !slices.Contains(types, t) {
+		return fmt.Errorf("%s validators cannot be applied to %s attributes", v.Type, t)
+	}
+
+	switch v.Type {
+	case LengthValidator, RangeValidator, SizeValidator:
+		if v.Min == nil && v.Max == nil {
+			return fmt.Errorf("%s validators must set at least one of min and max", v.Type)
+		}
+		if v.Min != nil && v.Max != nil && *v.Min > *v.Max {
+			return errors.New("min cannot be greater than max")
+		}
+	}
+	return nil
+}
+
+func (v Validator) Description(ctx context.Context) string {
+	switch v.Type {
+	case LengthValidator:
+		return "string length must be " + v.bounds()
+	case RegexValidator:
+		return fmt.Sprintf("value must match regular expression '%s'", v.Pattern)
+	case OneOfValidator:
+		values := make([]string, 0, len(v.Values))
+		for _, value := range v.Values {
+			values = append(values, valueToString(value))
+		}
+		return fmt.Sprintf("value must be one of: [%s]", strings.Join(values, ", "))
+	case RangeValidator:
+		return "value must be " + v.bounds()
+	case SizeValidator:
+		return "number of elements must be " + v.bounds()
+	default:
+		return ""
+	}
+}
+
+func (v Validator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v Validator) ValidateString(ctx context.Context, request validator.StringRequest, response *validator.StringResponse) {
+	if request.ConfigValue.IsNull() || request.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := request.ConfigValue.ValueString()
+	switch v.Type {
+	case LengthValidator:
+		length := utf8.RuneCountInString(value)
+		v.check(ctx, &response.Diagnostics, request.Path, v.inBounds(big.NewFloat(float64(length))), strconv.Itoa(length))
+	case RegexValidator:
+		pattern, err := regexp.Compile(v.Pattern)
+		if err != nil {
+			response.Diagnostics.AddAttributeError(request.Path, "Invalid Validator", err.Error())
+			return
+		}
+		v.check(ctx, &response.Diagnostics, request.Path, pattern.MatchString(value), strconv.Quote(value))
+	case OneOfValidator:
+		v.checkOneOf(ctx, &response.Diagnostics, request.Path, data.Value{String: &value})
+	}
+}
+
+func (v Validator) ValidateInt64(ctx context.Context, request validator.Int64Request, response *validator.Int64Response) {
+	if request.ConfigValue.IsNull() || request.ConfigValue.IsUnknown() {
+		return
+	}
+	v.validateNumber(ctx, &response.Diagnostics, request.Path, new(big.Float).SetInt64(request.ConfigValue.ValueInt64()))
+}
+
+func (v Validator) ValidateFloat64(ctx context.Context, request validator.Float64Request, response *validator.Float64Response) {
+	if request.ConfigValue.IsNull() || request.ConfigValue.IsUnknown() {
+		return
+	}
+	v.validateNumber(ctx, &response.Diagnostics, request.Path, big.NewFloat(request.ConfigValue.ValueFloat64()))
+}
+
+func (v Validator) ValidateNumber(ctx context.Context, request validator.NumberRequest, response *validator.NumberResponse) {
+	if request.ConfigValue.IsNull() || request.ConfigValue.IsUnknown() {
+		return
+	}
+	v.validateNumber(ctx, &response.Diagnostics, request.Path, request.ConfigValue.ValueBigFloat())
+}
+
+func (v Validator) ValidateList(ctx context.Context, request validator.ListRequest, response *validator.ListResponse) {
+	if request.ConfigValue.IsNull() || request.ConfigValue.IsUnknown() {
+		return
+	}
+	v.validateSize(ctx, &response.Diagnostics, request.Path, len(request.ConfigValue.Elements()))
+}
+
+func (v Validator) ValidateMap(ctx context.Context, request validator.MapRequest, response *validator.MapResponse) {
+	if request.ConfigValue.IsNull() || request.ConfigValue.IsUnknown() {
+		return
+	}
+	v.validateSize(ctx, &response.Diagnostics, request.Path, len(request.ConfigValue.Elements()))
+}
+
+func (v Validator) ValidateSet(ctx context.Context, request validator.SetRequest, response *validator.SetResponse) {
+	if request.ConfigValue.IsNull() || request.ConfigValue.IsUnknown() {
+		return
+	}
+	v.validateSize(ctx, &response.Diagnostics, request.Path, len(request.ConfigValue.Elements()))
+}
+
+// ValidateValue checks a value that doesn't come from the configuration, such
+// as the values a data source reads from the data directory, by calling the
+// framework validator that matches the type of the value.
+func (v Validator) ValidateValue(ctx context.Context, path path.Path, value attr.Value) diag.Diagnostics {
+	var diags diag.Diagnostics
+	switch value := value.(type) {
+	case types.String:
+		response := validator.StringResponse{}
+		v.ValidateString(ctx, validator.StringRequest{Path: path, PathExpression: path.Expression(), ConfigValue: value}, &response)
+		diags.Append(response.Diagnostics...)
+	case types.Int64:
+		response := validator.Int64Response{}
+		v.ValidateInt64(ctx, validator.Int64Request{Path: path, PathExpression: path.Expression(), ConfigValue: value}, &response)
+		diags.Append(response.Diagnostics...)
+	case types.Float64:
+		response := validator.Float64Response{}
+		v.ValidateFloat64(ctx, validator.Float64Request{Path: path, PathExpression: path.Expression(), ConfigValue: value}, &response)
+		diags.Append(response.Diagnostics...)
+	case types.Number:
+		response := validator.NumberResponse{}
+		v.ValidateNumber(ctx, validator.NumberRequest{Path: path, PathExpression: path.Expression(), ConfigValue: value}, &response)
+		diags.Append(response.Diagnostics...)
+	case types.List:
+		response := validator.ListResponse{}
+		v.ValidateList(ctx, validator.ListRequest{Path: path, PathExpression: path.Expression(), ConfigValue: value}, &response)
+		diags.Append(response.Diagnostics...)
+	case types.Map:
+		response := validator.MapResponse{}
+		v.ValidateMap(ctx, validator.MapRequest{Path: path, PathExpression: path.Expression(), ConfigValue: value}, &response)
+		diags.Append(response.Diagnostics...)
+	case types.Set:
+		response := validator.SetResponse{}
+		v.ValidateSet(ctx, validator.SetRequest{Path: path, PathExpression: path.Expression(), ConfigValue: value}, &response)
+		diags.Append(response.Diagnostics...)
+	}
+	return diags
+}
+
+func (v Validator) validateNumber(ctx context.Context, diags *diag.Diagnostics, path path.Path, value *big.Float) {
+	switch v.Type {
+	case RangeValidator:
+		v.check(ctx, diags, path, v.inBounds(value), value.Text('g', -1))
+	case OneOfValidator:
+		v.checkOneOf(ctx, diags, path, data.Value{Number: value})
+	}
+}
+
+func (v Validator) validateSize(ctx context.Context, diags *diag.Diagnostics, path path.Path, size int) {
+	if v.Type == SizeValidator {
+		v.check(ctx, diags, path, v.inBounds(big.NewFloat(float64(size))), strconv.Itoa(size))
+	}
+}
+
+func (v Validator) checkOneOf(ctx context.Context, diags *diag.Diagnostics, path path.Path, value data.Value) {
+	for _, allowed := range v.Values {
+		if allowed.Equal(value) {
+			return
+		}
+	}
+	v.check(ctx, diags, path, false, valueToString(value))
+}
+
+// check adds an error diagnostic for the attribute at path if the value isn't
+// valid. The detail is the custom message for the validator if there is one.
+func (v Validator) check(ctx context.Context, diags *diag.Diagnostics, path path.Path, valid bool, value string) {
+	if valid {
+		return
+	}
+
+	detail := v.Message
+	if len(detail) == 0 {
+		detail = fmt.Sprintf("Attribute %s %s, got: %s", path, v.Description(ctx), value)
+	}
+	diags.AddAttributeError(path, "Invalid Attribute Value", detail)
+}
+
+func (v Validator) inBounds(value *big.Float) bool {
+	if v.Min != nil && value.Cmp(big.NewFloat(*v.Min)) < 0 {
+		return false
+	}
+	if v.Max != nil && value.Cmp(big.NewFloat(*v.Max)) > 0 {
+		return false
+	}
+	return true
+}
+
+func (v Validator) bounds() string {
+	switch {
+	case v.Min != nil && v.Max != nil:
+		return fmt.Sprintf("between %s and %s", formatFloat(*v.Min), formatFloat(*v.Max))
+	case v.Min != nil:
+		return "at least " + formatFloat(*v.Min)
+	default:
+		return "at most " + formatFloat(*v.Max)
+	}
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+func valueToString(value data.Value) string {
+	switch {
+	case value.String != nil:
+		return strconv.Quote(*value.String)
+	case value.Number != nil:
+		return value.Number.Text('g', -1)
+	default:
+		return "null"
+	}
+}
+
+// AttributeValidators holds the validators of a single attribute, and an
+// expression matching every value of that attribute.
+type AttributeValidators struct {
+	Expression path.Expression
+	Validators []Validator
+}
+
+// AttributeValidators returns the validators for every attribute in the schema
+// that has any, including attributes within nested objects and blocks.
+func (schema Schema) AttributeValidators() []AttributeValidators {
+	return attributeValidators(nil, schema.Attributes, schema.Blocks)
+}
+
+func attributeValidators(parent *path.Expression, attributes map[string]Attribute, blocks map[string]Block) []AttributeValidators {
+	at := func(name string) path.Expression {
+		if parent == nil {
+			return path.MatchRoot(name)
+		}
+		return parent.AtName(name)
+	}
+
+	var out []AttributeValidators
+	for _, name := range slices.Sorted(maps.Keys(attributes)) {
+		out = append(out, attributes[name].attributeValidators(at(name))...)
+	}
+	for _, name := range slices.Sorted(maps.Keys(blocks)) {
+		block := blocks[name]
+		expression := at(name).AtAnyListIndex()
+		if block.Mode == NestingModeSet {
+			expression = at(name).AtAnySetValue()
+		}
+		out = append(out, attributeValidators(&expression, block.Attributes, block.Blocks)...)
+	}
+	return out
+}
+
+func (a Attribute) attributeValidators(expression path.Expression) []AttributeValidators {
+	var out []AttributeValidators
+	if len(a.Validators) > 0 {
+		out = append(out, AttributeValidators{Expression: expression, Validators: a.Validators})
+	}
+	if a.SkipNestedMetadata {
+		// Validation rejects validators within these objects.
+		return out
+	}
+
+	switch {
+	case a.Type == Object:
+		out = append(out, attributeValidators(&expression, a.Object, nil)...)
+	case a.List != nil && a.List.Type == Object:
+		out = append(out, a.List.attributeValidators(expression.AtAnyListIndex())...)
+	case a.Map != nil && a.Map.Type == Object:
+		out = append(out, a.Map.attributeValidators(expression.AtAnyMapKey())...)
+	case a.Set != nil && a.Set.Type == Object:
+		out = append(out, a.Set.attributeValidators(expression.AtAnySetValue())...)
+	}
+	return out
+}
+
+// validatorsAs converts the validators into the framework validator type V,
+// which every Validator implements.
+func validatorsAs[V any](validators []Validator) []V {
+	var out []V
+	for _, v := range validators {
+		out = append(out, any(v).(V))
+	}
+	return out
+}